@@ -0,0 +1,86 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"cloud.google.com/go/pubsub"
+)
+
+// AuditEvent is one append-only audit log entry: who changed what, and
+// when. Action is a short verb like "config.update" or "silence.create".
+type AuditEvent struct {
+	Time   time.Time `json:"time"`
+	Actor  string    `json:"actor"`
+	Action string    `json:"action"`
+	Detail string    `json:"detail,omitempty"`
+}
+
+// AuditLog appends AuditEvents as JSON lines to a local file, optionally
+// also publishing each to a Pub/Sub topic for centralized collection.
+type AuditLog struct {
+	mu    sync.Mutex
+	file  *os.File
+	topic *pubsub.Topic
+}
+
+// NewAuditLog opens (creating and appending to) the JSON-lines audit log
+// at path. If topicID is non-empty, every Record'd event is also
+// published to that Pub/Sub topic in projectID.
+func NewAuditLog(ctx context.Context, path, projectID, topicID string) (*AuditLog, error) {
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("auth: opening audit log: %w", err)
+	}
+
+	var topic *pubsub.Topic
+	if topicID != "" {
+		client, err := pubsub.NewClient(ctx, projectID)
+		if err != nil {
+			f.Close()
+			return nil, fmt.Errorf("auth: creating pubsub client: %w", err)
+		}
+		topic = client.Topic(topicID)
+	}
+
+	return &AuditLog{file: f, topic: topic}, nil
+}
+
+// Record appends event to the log and, if configured, publishes it to the
+// audit Pub/Sub topic. A failure to publish is logged by the caller via
+// the returned error; it does not undo the local append.
+func (a *AuditLog) Record(ctx context.Context, event AuditEvent) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("auth: marshaling audit event: %w", err)
+	}
+	data = append(data, '\n')
+
+	a.mu.Lock()
+	_, writeErr := a.file.Write(data)
+	a.mu.Unlock()
+	if writeErr != nil {
+		return fmt.Errorf("auth: writing audit log: %w", writeErr)
+	}
+
+	if a.topic == nil {
+		return nil
+	}
+	result := a.topic.Publish(ctx, &pubsub.Message{Data: data})
+	if _, err := result.Get(ctx); err != nil {
+		return fmt.Errorf("auth: publishing audit event: %w", err)
+	}
+	return nil
+}
+
+// Close releases the audit log's file handle and Pub/Sub topic.
+func (a *AuditLog) Close() error {
+	if a.topic != nil {
+		a.topic.Stop()
+	}
+	return a.file.Close()
+}
@@ -0,0 +1,195 @@
+// Package auth gates p2s's admin HTTP endpoints (routing config, silences,
+// finding acknowledgements) behind an operator-chosen authentication mode,
+// and pairs it with CSRF protection for the browser-facing dashboard forms.
+package auth
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/coreos/go-oidc/v3/oidc"
+	"github.com/gin-gonic/gin"
+	"gopkg.in/yaml.v3"
+)
+
+// Mode selects how Middleware authenticates a request.
+type Mode string
+
+const (
+	// ModeNone disables authentication. This is the default so existing
+	// deployments keep working until an operator opts in.
+	ModeNone Mode = "none"
+	// ModeBasic requires HTTP Basic auth against a single configured
+	// username/password.
+	ModeBasic Mode = "basic"
+	// ModeToken requires a static bearer token, e.g. for CI callers.
+	ModeToken Mode = "token"
+	// ModeOIDC requires a bearer access token issued by an OIDC provider,
+	// verified against the provider's JWKS.
+	ModeOIDC Mode = "oidc"
+)
+
+// Config is the top-level auth configuration, loaded from auth.yaml.
+type Config struct {
+	Mode  Mode         `yaml:"mode"`
+	Basic *BasicConfig `yaml:"basic,omitempty"`
+	Token *TokenConfig `yaml:"token,omitempty"`
+	OIDC  *OIDCConfig  `yaml:"oidc,omitempty"`
+}
+
+// BasicConfig configures ModeBasic.
+type BasicConfig struct {
+	Username string `yaml:"username"`
+	Password string `yaml:"password"`
+}
+
+// TokenConfig configures ModeToken.
+type TokenConfig struct {
+	Value string `yaml:"value"`
+}
+
+// OIDCConfig configures ModeOIDC. ClientID is the audience p2s expects on
+// verified access tokens.
+type OIDCConfig struct {
+	IssuerURL string `yaml:"issuer_url"`
+	ClientID  string `yaml:"client_id"`
+}
+
+// LoadConfig reads and validates an auth Config from a YAML file.
+func LoadConfig(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("auth: reading config: %w", err)
+	}
+	var cfg Config
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("auth: parsing config: %w", err)
+	}
+	if cfg.Mode == "" {
+		cfg.Mode = ModeNone
+	}
+	switch cfg.Mode {
+	case ModeNone:
+	case ModeBasic:
+		if cfg.Basic == nil || cfg.Basic.Username == "" || cfg.Basic.Password == "" {
+			return nil, fmt.Errorf("auth: mode %q requires basic.username and basic.password", cfg.Mode)
+		}
+	case ModeToken:
+		if cfg.Token == nil || cfg.Token.Value == "" {
+			return nil, fmt.Errorf("auth: mode %q requires token.value", cfg.Mode)
+		}
+	case ModeOIDC:
+		if cfg.OIDC == nil || cfg.OIDC.IssuerURL == "" || cfg.OIDC.ClientID == "" {
+			return nil, fmt.Errorf("auth: mode %q requires oidc.issuer_url and oidc.client_id", cfg.Mode)
+		}
+	default:
+		return nil, fmt.Errorf("auth: unknown mode %q", cfg.Mode)
+	}
+	return &cfg, nil
+}
+
+// Middleware builds the gin.HandlerFunc that enforces cfg's mode, calling
+// c.Next() on success and aborting with 401 otherwise. On success the
+// handler stores the authenticated user/subject on the context, where the
+// audit log reads it via Identity.
+func Middleware(cfg *Config) (gin.HandlerFunc, error) {
+	switch cfg.Mode {
+	case ModeNone, "":
+		return func(c *gin.Context) { c.Next() }, nil
+	case ModeBasic:
+		return basicAuth(cfg.Basic), nil
+	case ModeToken:
+		return tokenAuth(cfg.Token), nil
+	case ModeOIDC:
+		return oidcAuth(cfg.OIDC)
+	default:
+		return nil, fmt.Errorf("auth: unknown mode %q", cfg.Mode)
+	}
+}
+
+// identityKey is the gin.Context key Middleware stores the authenticated
+// identity under, for the audit log to read via Identity.
+const identityKey = "p2s.auth.identity"
+
+// Identity returns the identity Middleware authenticated the request as,
+// or "" if the request was unauthenticated (ModeNone, or no identity was
+// recorded).
+func Identity(c *gin.Context) string {
+	v, _ := c.Get(identityKey)
+	s, _ := v.(string)
+	return s
+}
+
+func basicAuth(cfg *BasicConfig) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		username, password, ok := c.Request.BasicAuth()
+		if !ok || !constantTimeEqual(username, cfg.Username) || !constantTimeEqual(password, cfg.Password) {
+			c.Header("WWW-Authenticate", `Basic realm="p2s"`)
+			c.AbortWithStatus(http.StatusUnauthorized)
+			return
+		}
+		c.Set(identityKey, username)
+		c.Next()
+	}
+}
+
+func tokenAuth(cfg *TokenConfig) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		token, ok := bearerToken(c.Request)
+		if !ok || !constantTimeEqual(token, cfg.Value) {
+			c.AbortWithStatus(http.StatusUnauthorized)
+			return
+		}
+		c.Set(identityKey, "token")
+		c.Next()
+	}
+}
+
+// oidcAuth verifies the bearer token on every request against cfg's issuer
+// using its published JWKS, rather than a local secret, so the token
+// itself attests the caller's identity.
+func oidcAuth(cfg *OIDCConfig) (gin.HandlerFunc, error) {
+	provider, err := oidc.NewProvider(context.Background(), cfg.IssuerURL)
+	if err != nil {
+		return nil, fmt.Errorf("auth: discovering oidc provider %q: %w", cfg.IssuerURL, err)
+	}
+	verifier := provider.Verifier(&oidc.Config{ClientID: cfg.ClientID})
+
+	return func(c *gin.Context) {
+		token, ok := bearerToken(c.Request)
+		if !ok {
+			c.AbortWithStatus(http.StatusUnauthorized)
+			return
+		}
+		idToken, err := verifier.Verify(c.Request.Context(), token)
+		if err != nil {
+			c.AbortWithStatus(http.StatusUnauthorized)
+			return
+		}
+		c.Set(identityKey, idToken.Subject)
+		c.Next()
+	}, nil
+}
+
+func bearerToken(r *http.Request) (string, bool) {
+	h := r.Header.Get("Authorization")
+	const prefix = "Bearer "
+	if !strings.HasPrefix(h, prefix) {
+		return "", false
+	}
+	return strings.TrimPrefix(h, prefix), true
+}
+
+// constantTimeEqual compares a and b without leaking their equality via
+// timing, even when they differ in length: it compares fixed-size SHA-256
+// digests of both rather than the strings themselves.
+func constantTimeEqual(a, b string) bool {
+	ah := sha256.Sum256([]byte(a))
+	bh := sha256.Sum256([]byte(b))
+	return subtle.ConstantTimeCompare(ah[:], bh[:]) == 1
+}
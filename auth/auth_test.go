@@ -0,0 +1,142 @@
+package auth
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func init() {
+	gin.SetMode(gin.TestMode)
+}
+
+func newRouter(h gin.HandlerFunc) *gin.Engine {
+	r := gin.New()
+	r.GET("/protected", h, func(c *gin.Context) { c.Status(http.StatusOK) })
+	return r
+}
+
+func TestMiddlewareModeNoneAllowsAll(t *testing.T) {
+	h, err := Middleware(&Config{Mode: ModeNone})
+	if err != nil {
+		t.Fatalf("Middleware: %v", err)
+	}
+	r := newRouter(h)
+
+	req := httptest.NewRequest(http.MethodGet, "/protected", nil)
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusOK)
+	}
+}
+
+func TestMiddlewareModeBasic(t *testing.T) {
+	h, err := Middleware(&Config{Mode: ModeBasic, Basic: &BasicConfig{Username: "alice", Password: "s3cret"}})
+	if err != nil {
+		t.Fatalf("Middleware: %v", err)
+	}
+	r := newRouter(h)
+
+	req := httptest.NewRequest(http.MethodGet, "/protected", nil)
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("status with no credentials = %d, want %d", rec.Code, http.StatusUnauthorized)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/protected", nil)
+	req.SetBasicAuth("alice", "wrong")
+	rec = httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("status with wrong password = %d, want %d", rec.Code, http.StatusUnauthorized)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/protected", nil)
+	req.SetBasicAuth("alice", "s3cret")
+	rec = httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Errorf("status with valid credentials = %d, want %d", rec.Code, http.StatusOK)
+	}
+}
+
+func TestMiddlewareModeToken(t *testing.T) {
+	h, err := Middleware(&Config{Mode: ModeToken, Token: &TokenConfig{Value: "ci-token"}})
+	if err != nil {
+		t.Fatalf("Middleware: %v", err)
+	}
+	r := newRouter(h)
+
+	req := httptest.NewRequest(http.MethodGet, "/protected", nil)
+	req.Header.Set("Authorization", "Bearer wrong-token")
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("status with wrong token = %d, want %d", rec.Code, http.StatusUnauthorized)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/protected", nil)
+	req.Header.Set("Authorization", "Bearer ci-token")
+	rec = httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Errorf("status with valid token = %d, want %d", rec.Code, http.StatusOK)
+	}
+}
+
+func TestLoadConfigRejectsIncompleteMode(t *testing.T) {
+	dir := t.TempDir()
+	path := dir + "/auth.yaml"
+	if err := os.WriteFile(path, []byte("mode: basic\n"), 0644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	if _, err := LoadConfig(path); err == nil {
+		t.Error("expected error loading basic mode config without basic.username/password")
+	}
+}
+
+func TestCSRFRequiresMatchingToken(t *testing.T) {
+	r := gin.New()
+	r.Use(CSRF())
+	r.GET("/form", func(c *gin.Context) { c.String(http.StatusOK, CSRFToken(c)) })
+	r.POST("/submit", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	getReq := httptest.NewRequest(http.MethodGet, "/form", nil)
+	getRec := httptest.NewRecorder()
+	r.ServeHTTP(getRec, getReq)
+
+	var cookie *http.Cookie
+	for _, c := range getRec.Result().Cookies() {
+		if c.Name == csrfCookie {
+			cookie = c
+		}
+	}
+	if cookie == nil {
+		t.Fatal("expected CSRF cookie to be set")
+	}
+	token := getRec.Body.String()
+
+	// No token: rejected.
+	postReq := httptest.NewRequest(http.MethodPost, "/submit", nil)
+	postReq.AddCookie(cookie)
+	postRec := httptest.NewRecorder()
+	r.ServeHTTP(postRec, postReq)
+	if postRec.Code != http.StatusForbidden {
+		t.Errorf("status with no CSRF header = %d, want %d", postRec.Code, http.StatusForbidden)
+	}
+
+	// Matching token: allowed.
+	postReq = httptest.NewRequest(http.MethodPost, "/submit", nil)
+	postReq.AddCookie(cookie)
+	postReq.Header.Set(csrfHeader, token)
+	postRec = httptest.NewRecorder()
+	r.ServeHTTP(postRec, postReq)
+	if postRec.Code != http.StatusOK {
+		t.Errorf("status with matching CSRF header = %d, want %d", postRec.Code, http.StatusOK)
+	}
+}
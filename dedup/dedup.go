@@ -0,0 +1,62 @@
+// Package dedup suppresses re-notifying on the same finding within a
+// configurable window, so a Pub/Sub redelivery of an already-handled
+// Vulnerability doesn't spam every configured sink again.
+package dedup
+
+import (
+	"sync"
+	"time"
+
+	"github.com/alexfun1/p2s/vuln"
+)
+
+// Deduper remembers the last time each finding fingerprint was allowed
+// through, keyed on (type, package_name, resource_name, severity).
+type Deduper struct {
+	mu             sync.Mutex
+	repeatInterval time.Duration
+	lastSeen       map[string]time.Time
+	lastSwept      time.Time
+}
+
+// NewDeduper builds a Deduper that re-allows a fingerprint once
+// repeatInterval has elapsed since it last passed.
+func NewDeduper(repeatInterval time.Duration) *Deduper {
+	return &Deduper{repeatInterval: repeatInterval, lastSeen: make(map[string]time.Time)}
+}
+
+// Allow reports whether v should be passed on: true the first time its
+// fingerprint is seen, or again once repeatInterval has elapsed since the
+// last time it was allowed.
+func (d *Deduper) Allow(v vuln.Vulnerability, now time.Time) bool {
+	key := vuln.Fingerprint(v)
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.sweep(now)
+
+	if last, ok := d.lastSeen[key]; ok && now.Sub(last) < d.repeatInterval {
+		return false
+	}
+	d.lastSeen[key] = now
+	return true
+}
+
+// sweep drops fingerprints that haven't been seen in over repeatInterval,
+// the same class of fix applied to router.Grouper: without it, lastSeen
+// grows by one entry per unique fingerprint ever observed and never
+// shrinks, even after a finding stops recurring. Sweeping is itself
+// throttled to once per repeatInterval so Allow stays cheap on the common
+// path.
+func (d *Deduper) sweep(now time.Time) {
+	if !d.lastSwept.IsZero() && now.Sub(d.lastSwept) < d.repeatInterval {
+		return
+	}
+	d.lastSwept = now
+	for key, last := range d.lastSeen {
+		if now.Sub(last) >= d.repeatInterval {
+			delete(d.lastSeen, key)
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package dedup
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alexfun1/p2s/vuln"
+)
+
+func TestDeduperAllow(t *testing.T) {
+	d := NewDeduper(time.Hour)
+	now := time.Now()
+	v := vuln.Vulnerability{Type: "OS", PackageName: "openssl", ResourceName: "vm-1", Severity: "HIGH"}
+
+	if !d.Allow(v, now) {
+		t.Error("expected first sighting to be allowed")
+	}
+	if d.Allow(v, now.Add(time.Minute)) {
+		t.Error("expected redelivery within repeat_interval to be suppressed")
+	}
+	if !d.Allow(v, now.Add(2*time.Hour)) {
+		t.Error("expected finding to be allowed again after repeat_interval elapses")
+	}
+}
+
+func TestDeduperSweepsStaleFingerprints(t *testing.T) {
+	d := NewDeduper(time.Hour)
+	now := time.Now()
+
+	stale := vuln.Vulnerability{Type: "OS", PackageName: "openssl", ResourceName: "vm-1", Severity: "HIGH"}
+	d.Allow(stale, now)
+	if len(d.lastSeen) != 1 {
+		t.Fatalf("lastSeen has %d entries after first sighting, want 1", len(d.lastSeen))
+	}
+
+	// A different fingerprint arriving well past repeat_interval should
+	// trigger a sweep that drops the now-stale entry instead of keeping it
+	// around forever.
+	fresh := vuln.Vulnerability{Type: "OS", PackageName: "curl", ResourceName: "vm-2", Severity: "HIGH"}
+	d.Allow(fresh, now.Add(3*time.Hour))
+
+	if _, ok := d.lastSeen[vuln.Fingerprint(stale)]; ok {
+		t.Error("expected stale fingerprint to be swept after repeat_interval elapsed")
+	}
+	if len(d.lastSeen) != 1 {
+		t.Errorf("lastSeen has %d entries after sweep, want 1 (just the fresh fingerprint)", len(d.lastSeen))
+	}
+}
@@ -0,0 +1,101 @@
+package ingest
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/alexfun1/p2s/vuln"
+)
+
+// AlertmanagerWebhook receives Prometheus Alertmanager's webhook_config
+// payload at POST /alerts and translates each firing alert's labels and
+// annotations into a Vulnerability.
+type AlertmanagerWebhook struct {
+	// CategoryLabel is the alert label read to derive the Vulnerability's
+	// Type (default "category").
+	CategoryLabel string
+	// TypeMap remaps a raw category label value (e.g. "os-package") to a
+	// p2s Type (e.g. "OS"). Values with no entry are upper-cased as-is.
+	TypeMap map[string]string
+	// PackageLabel and ResourceLabel name the alert labels that carry the
+	// affected package and resource (default "package_name" /
+	// "resource_name").
+	PackageLabel  string
+	ResourceLabel string
+	// DescriptionAnnotation names the alert annotation used as the
+	// Vulnerability's Description (default "description").
+	DescriptionAnnotation string
+}
+
+// NewAlertmanagerWebhook builds an AlertmanagerWebhook ingester with the
+// conventional label/annotation names.
+func NewAlertmanagerWebhook() *AlertmanagerWebhook {
+	return &AlertmanagerWebhook{
+		CategoryLabel:         "category",
+		PackageLabel:          "package_name",
+		ResourceLabel:         "resource_name",
+		DescriptionAnnotation: "description",
+	}
+}
+
+func (a *AlertmanagerWebhook) Name() string { return "alertmanager" }
+
+// alertmanagerPayload is the subset of Alertmanager's webhook_config JSON
+// body that matters to p2s. See
+// https://prometheus.io/docs/alerting/latest/configuration/#webhook_config.
+type alertmanagerPayload struct {
+	Alerts []alertmanagerAlert `json:"alerts"`
+}
+
+type alertmanagerAlert struct {
+	Status      string            `json:"status"`
+	Labels      map[string]string `json:"labels"`
+	Annotations map[string]string `json:"annotations"`
+}
+
+// Register mounts POST /alerts, which parses the Alertmanager webhook
+// payload and calls handle once per firing alert.
+func (a *AlertmanagerWebhook) Register(r *gin.Engine, handle Handler) {
+	r.POST("/alerts", func(c *gin.Context) {
+		var payload alertmanagerPayload
+		if err := c.ShouldBindJSON(&payload); err != nil {
+			c.String(http.StatusBadRequest, "%v", err)
+			return
+		}
+		for _, alert := range payload.Alerts {
+			if alert.Status != "firing" {
+				continue
+			}
+			handle(a.toVulnerability(alert))
+		}
+		c.Status(http.StatusOK)
+	})
+}
+
+// toVulnerability maps alert's labels/annotations onto a Vulnerability,
+// carrying every label through as-is on Labels so routes can still match
+// on anything Alertmanager sent that p2s doesn't have a dedicated field
+// for.
+func (a *AlertmanagerWebhook) toVulnerability(alert alertmanagerAlert) vuln.Vulnerability {
+	category := alert.Labels[a.CategoryLabel]
+	typ, ok := a.TypeMap[category]
+	if !ok {
+		typ = strings.ToUpper(category)
+	}
+
+	labels := make(map[string]string, len(alert.Labels))
+	for k, v := range alert.Labels {
+		labels[k] = v
+	}
+
+	return vuln.Vulnerability{
+		Severity:     strings.ToUpper(alert.Labels["severity"]),
+		Type:         typ,
+		Description:  alert.Annotations[a.DescriptionAnnotation],
+		PackageName:  alert.Labels[a.PackageLabel],
+		ResourceName: alert.Labels[a.ResourceLabel],
+		Labels:       labels,
+	}
+}
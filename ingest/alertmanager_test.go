@@ -0,0 +1,47 @@
+package ingest
+
+import "testing"
+
+func TestAlertmanagerWebhookToVulnerability(t *testing.T) {
+	a := NewAlertmanagerWebhook()
+
+	v := a.toVulnerability(alertmanagerAlert{
+		Status: "firing",
+		Labels: map[string]string{
+			"severity":      "critical",
+			"category":      "os",
+			"package_name":  "openssl",
+			"resource_name": "vm-1",
+		},
+		Annotations: map[string]string{"description": "CVE-2024-0001"},
+	})
+
+	if v.Severity != "CRITICAL" {
+		t.Errorf("Severity = %q, want CRITICAL", v.Severity)
+	}
+	if v.Type != "OS" {
+		t.Errorf("Type = %q, want OS", v.Type)
+	}
+	if v.PackageName != "openssl" || v.ResourceName != "vm-1" {
+		t.Errorf("PackageName/ResourceName = %q/%q, want openssl/vm-1", v.PackageName, v.ResourceName)
+	}
+	if v.Description != "CVE-2024-0001" {
+		t.Errorf("Description = %q, want CVE-2024-0001", v.Description)
+	}
+	if v.Labels["category"] != "os" {
+		t.Errorf("Labels[category] = %q, want os", v.Labels["category"])
+	}
+}
+
+func TestAlertmanagerWebhookTypeMap(t *testing.T) {
+	a := NewAlertmanagerWebhook()
+	a.TypeMap = map[string]string{"os-package": "OS"}
+
+	v := a.toVulnerability(alertmanagerAlert{
+		Labels: map[string]string{"category": "os-package"},
+	})
+
+	if v.Type != "OS" {
+		t.Errorf("Type = %q, want OS", v.Type)
+	}
+}
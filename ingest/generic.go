@@ -0,0 +1,32 @@
+package ingest
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/alexfun1/p2s/vuln"
+)
+
+// Generic receives the native Vulnerability JSON shape at POST /ingest, for
+// easy integration with curl or a CI job that already knows p2s's schema.
+type Generic struct{}
+
+// NewGeneric builds a Generic ingester.
+func NewGeneric() *Generic { return &Generic{} }
+
+func (g *Generic) Name() string { return "generic" }
+
+// Register mounts POST /ingest, which parses the request body as a single
+// Vulnerability and calls handle with it.
+func (g *Generic) Register(r *gin.Engine, handle Handler) {
+	r.POST("/ingest", func(c *gin.Context) {
+		var v vuln.Vulnerability
+		if err := c.ShouldBindJSON(&v); err != nil {
+			c.String(http.StatusBadRequest, "%v", err)
+			return
+		}
+		handle(v)
+		c.Status(http.StatusAccepted)
+	})
+}
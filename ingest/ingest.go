@@ -0,0 +1,38 @@
+// Package ingest defines p2s's pluggable input sources. Every ingester,
+// whatever its transport, ends up calling the same handler with a parsed
+// vuln.Vulnerability, so it flows through the same routing/notify
+// pipeline as every other source.
+package ingest
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/alexfun1/p2s/vuln"
+)
+
+// Handler processes one ingested Vulnerability, typically by routing it
+// through silencing/inhibition/dedup and on to notify sinks.
+type Handler func(vuln.Vulnerability)
+
+// Ingester is any input source p2s can be configured to read from.
+type Ingester interface {
+	// Name identifies the ingester in logs.
+	Name() string
+}
+
+// PullIngester is an Ingester that actively pulls findings from a remote
+// source, such as a GCP Pub/Sub subscription. Run blocks until ctx is
+// done or an unrecoverable error occurs.
+type PullIngester interface {
+	Ingester
+	Run(ctx context.Context, handle Handler) error
+}
+
+// HTTPIngester is an Ingester that receives findings pushed to it over
+// HTTP. Register mounts its route(s) on r.
+type HTTPIngester interface {
+	Ingester
+	Register(r *gin.Engine, handle Handler)
+}
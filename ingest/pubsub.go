@@ -0,0 +1,48 @@
+package ingest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"cloud.google.com/go/pubsub"
+
+	"github.com/alexfun1/p2s/vuln"
+)
+
+// PubSub pulls Vulnerability findings off a GCP Pub/Sub subscription,
+// the original (and still default) way p2s is fed.
+type PubSub struct {
+	ProjectID    string
+	Subscription string
+}
+
+// NewPubSub builds a PubSub ingester for the given project and
+// subscription.
+func NewPubSub(projectID, subscription string) *PubSub {
+	return &PubSub{ProjectID: projectID, Subscription: subscription}
+}
+
+func (p *PubSub) Name() string { return "pubsub" }
+
+// Run opens a pull subscription and invokes handle for every message that
+// parses as a Vulnerability, acking on success and nacking on a parse
+// failure so the message isn't silently dropped.
+func (p *PubSub) Run(ctx context.Context, handle Handler) error {
+	client, err := pubsub.NewClient(ctx, p.ProjectID)
+	if err != nil {
+		return fmt.Errorf("ingest: creating pubsub client: %w", err)
+	}
+	sub := client.Subscription(p.Subscription)
+	return sub.Receive(ctx, func(ctx context.Context, msg *pubsub.Message) {
+		var v vuln.Vulnerability
+		if err := json.Unmarshal(msg.Data, &v); err != nil {
+			log.Printf("ingest: pubsub: invalid message format: %v", err)
+			msg.Nack()
+			return
+		}
+		handle(v)
+		msg.Ack()
+	})
+}
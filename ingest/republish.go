@@ -0,0 +1,41 @@
+package ingest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"cloud.google.com/go/pubsub"
+
+	"github.com/alexfun1/p2s/vuln"
+)
+
+// Republisher publishes every Vulnerability it's given to an outbound
+// Pub/Sub topic instead of routing it locally, so the same binary can run
+// as a bridge in front of other p2s (or third-party) consumers rather than
+// as a terminal notifier.
+type Republisher struct {
+	topic *pubsub.Topic
+}
+
+// NewRepublisher opens a client for projectID and returns a Republisher
+// that publishes onto topicID.
+func NewRepublisher(ctx context.Context, projectID, topicID string) (*Republisher, error) {
+	client, err := pubsub.NewClient(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("ingest: creating pubsub client: %w", err)
+	}
+	return &Republisher{topic: client.Topic(topicID)}, nil
+}
+
+// Publish marshals v and publishes it to the outbound topic, waiting for
+// the publish to be acknowledged by the Pub/Sub service.
+func (r *Republisher) Publish(ctx context.Context, v vuln.Vulnerability) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("ingest: marshaling vulnerability: %w", err)
+	}
+	result := r.topic.Publish(ctx, &pubsub.Message{Data: data})
+	_, err = result.Get(ctx)
+	return err
+}
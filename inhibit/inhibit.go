@@ -0,0 +1,148 @@
+// Package inhibit implements Alertmanager-style inhibition: a higher
+// severity finding for a resource can suppress lower severity findings on
+// the same resource for a configured window, instead of paging once per
+// severity level.
+package inhibit
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/alexfun1/p2s/matcher"
+	"github.com/alexfun1/p2s/router"
+)
+
+// Rule mirrors Alertmanager's inhibit_rule: if a finding matching
+// Source{Match,MatchRe} is seen, any finding matching Target{Match,MatchRe}
+// that shares the same values for every label in Equal is suppressed for
+// Duration.
+type Rule struct {
+	SourceMatch   map[string]string `yaml:"source_match,omitempty"`
+	SourceMatchRe map[string]string `yaml:"source_match_re,omitempty"`
+	TargetMatch   map[string]string `yaml:"target_match,omitempty"`
+	TargetMatchRe map[string]string `yaml:"target_match_re,omitempty"`
+	Equal         []string          `yaml:"equal,omitempty"`
+	Duration      router.Duration   `yaml:"duration"`
+}
+
+// Config is the top-level inhibition configuration: a flat list of rules.
+type Config struct {
+	Rules []Rule `yaml:"rules"`
+}
+
+// LoadConfig reads and compiles an inhibition Config from a YAML file.
+func LoadConfig(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("inhibit: reading config: %w", err)
+	}
+	var cfg Config
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("inhibit: parsing config: %w", err)
+	}
+	return &cfg, nil
+}
+
+// sweepInterval bounds how often Observe prunes expired active entries.
+// Without it, active grows by one entry per rule+Equal-key combination
+// ever observed and never shrinks, even once its suppression window has
+// long since passed: the same leak class fixed for router.Grouper.
+const sweepInterval = time.Minute
+
+type compiledRule struct {
+	rule           Rule
+	sourceMatchers []matcher.Matcher
+	targetMatchers []matcher.Matcher
+}
+
+// Inhibitor tracks, per rule, the most recent source match for each
+// combination of Equal label values, and uses it to suppress matching
+// target findings until that window expires.
+type Inhibitor struct {
+	mu        sync.Mutex
+	rules     []compiledRule
+	active    map[string]time.Time // "<ruleIdx>\x00<equalKey>" -> suppressed until
+	lastSwept time.Time
+}
+
+// NewInhibitor compiles rules into an Inhibitor.
+func NewInhibitor(rules []Rule) (*Inhibitor, error) {
+	compiled := make([]compiledRule, len(rules))
+	for i, r := range rules {
+		sm, err := matcher.Compile(r.SourceMatch, r.SourceMatchRe)
+		if err != nil {
+			return nil, fmt.Errorf("inhibit: rule %d source matchers: %w", i, err)
+		}
+		tm, err := matcher.Compile(r.TargetMatch, r.TargetMatchRe)
+		if err != nil {
+			return nil, fmt.Errorf("inhibit: rule %d target matchers: %w", i, err)
+		}
+		compiled[i] = compiledRule{rule: r, sourceMatchers: sm, targetMatchers: tm}
+	}
+	return &Inhibitor{rules: compiled, active: make(map[string]time.Time)}, nil
+}
+
+// Observe records labels as a potential inhibition source: for every rule
+// whose source matchers it satisfies, findings matching that rule's target
+// and sharing its Equal label values are suppressed until now+rule.Duration.
+func (in *Inhibitor) Observe(labels map[string]string, now time.Time) {
+	in.mu.Lock()
+	defer in.mu.Unlock()
+	in.sweep(now)
+	for i, cr := range in.rules {
+		if !matcher.MatchesAll(cr.sourceMatchers, labels) {
+			continue
+		}
+		in.active[equalKey(i, cr.rule.Equal, labels)] = now.Add(cr.rule.Duration.Duration())
+	}
+}
+
+// Inhibited reports whether labels is currently suppressed by an earlier
+// Observe'd source finding under any rule.
+func (in *Inhibitor) Inhibited(labels map[string]string, now time.Time) bool {
+	in.mu.Lock()
+	defer in.mu.Unlock()
+	for i, cr := range in.rules {
+		if !matcher.MatchesAll(cr.targetMatchers, labels) {
+			continue
+		}
+		until, ok := in.active[equalKey(i, cr.rule.Equal, labels)]
+		if ok && now.Before(until) {
+			return true
+		}
+	}
+	return false
+}
+
+// sweep drops active entries whose suppression window has already
+// passed, throttled to once per sweepInterval so Observe stays cheap on
+// the common path.
+func (in *Inhibitor) sweep(now time.Time) {
+	if !in.lastSwept.IsZero() && now.Sub(in.lastSwept) < sweepInterval {
+		return
+	}
+	in.lastSwept = now
+	for key, until := range in.active {
+		if !now.Before(until) {
+			delete(in.active, key)
+		}
+	}
+}
+
+// equalKey builds the key Observe and Inhibited use to correlate a source
+// and target finding for rule ruleIdx: the rule's Equal label values,
+// joined in order.
+func equalKey(ruleIdx int, equal []string, labels map[string]string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d\x00", ruleIdx)
+	for _, label := range equal {
+		b.WriteString(labels[label])
+		b.WriteByte('\x00')
+	}
+	return b.String()
+}
@@ -0,0 +1,65 @@
+package inhibit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alexfun1/p2s/router"
+)
+
+func TestInhibitorSuppressesMatchingTarget(t *testing.T) {
+	in, err := NewInhibitor([]Rule{
+		{
+			SourceMatch: map[string]string{"type": "OS", "severity": "CRITICAL"},
+			TargetMatch: map[string]string{"type": "OS"},
+			TargetMatchRe: map[string]string{
+				"severity": "LOW|MEDIUM",
+			},
+			Equal:    []string{"resource_name"},
+			Duration: router.Duration(time.Hour),
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewInhibitor: %v", err)
+	}
+
+	now := time.Now()
+	in.Observe(map[string]string{"type": "OS", "severity": "CRITICAL", "resource_name": "vm-1"}, now)
+
+	if !in.Inhibited(map[string]string{"type": "OS", "severity": "MEDIUM", "resource_name": "vm-1"}, now) {
+		t.Error("expected same-resource MEDIUM finding to be inhibited")
+	}
+	if in.Inhibited(map[string]string{"type": "OS", "severity": "MEDIUM", "resource_name": "vm-2"}, now) {
+		t.Error("expected different-resource finding to be unaffected")
+	}
+	if in.Inhibited(map[string]string{"type": "OS", "severity": "MEDIUM", "resource_name": "vm-1"}, now.Add(2*time.Hour)) {
+		t.Error("expected inhibition to expire after its duration")
+	}
+}
+
+func TestInhibitorSweepsExpiredActiveEntries(t *testing.T) {
+	in, err := NewInhibitor([]Rule{
+		{
+			SourceMatch: map[string]string{"type": "OS", "severity": "CRITICAL"},
+			TargetMatch: map[string]string{"type": "OS"},
+			Equal:       []string{"resource_name"},
+			Duration:    router.Duration(time.Second),
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewInhibitor: %v", err)
+	}
+
+	now := time.Now()
+	in.Observe(map[string]string{"type": "OS", "severity": "CRITICAL", "resource_name": "vm-1"}, now)
+	if len(in.active) != 1 {
+		t.Fatalf("active has %d entries after Observe, want 1", len(in.active))
+	}
+
+	// A later Observe, well past sweepInterval, should prune the
+	// long-expired entry instead of leaving it in active forever.
+	in.Observe(map[string]string{"type": "OS", "severity": "CRITICAL", "resource_name": "vm-2"}, now.Add(2*sweepInterval))
+	if len(in.active) != 1 {
+		t.Errorf("active has %d entries after sweep, want 1 (just the fresh entry)", len(in.active))
+	}
+}
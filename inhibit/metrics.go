@@ -0,0 +1,22 @@
+package inhibit
+
+import "github.com/prometheus/client_golang/prometheus"
+
+// inhibitedTotal counts findings suppressed because a higher-severity
+// source finding already covers the same resource.
+var inhibitedTotal = prometheus.NewCounter(
+	prometheus.CounterOpts{
+		Name: "vulns_inhibited_total",
+		Help: "Total number of vulnerability findings suppressed by an active inhibition rule",
+	},
+)
+
+func init() {
+	prometheus.MustRegister(inhibitedTotal)
+}
+
+// RecordInhibited increments the vulns_inhibited_total counter. Callers
+// invoke it once per finding suppressed by Inhibitor.Inhibited.
+func RecordInhibited() {
+	inhibitedTotal.Inc()
+}
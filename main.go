@@ -2,50 +2,42 @@ package main
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"html/template"
+	"io"
 	"log"
 	"net/http"
 	"os"
+	"strconv"
 	"strings"
-	"sync"
+	"time"
 
-	"cloud.google.com/go/pubsub"
 	"github.com/gin-gonic/gin"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
-)
-
-type Vulnerability struct {
-	Severity     string `json:"severity"`
-	Type         string `json:"type"` // "OS" or "APP"
-	Description  string `json:"description"`
-	PackageName  string `json:"package_name"`
-	ResourceName string `json:"resource_name"`
-}
 
-type Config struct {
-	OSChannelName  string
-	OSMinSeverity  string
-	AppChannelName string
-	AppMinSeverity string
-	Mutex          sync.RWMutex
-}
+	"github.com/alexfun1/p2s/auth"
+	"github.com/alexfun1/p2s/dedup"
+	"github.com/alexfun1/p2s/ingest"
+	"github.com/alexfun1/p2s/inhibit"
+	"github.com/alexfun1/p2s/notify"
+	"github.com/alexfun1/p2s/router"
+	"github.com/alexfun1/p2s/silence"
+	"github.com/alexfun1/p2s/store"
+	"github.com/alexfun1/p2s/vuln"
+)
 
-var config = Config{
-	OSChannelName:  "#os-vulns",
-	OSMinSeverity:  "MEDIUM",
-	AppChannelName: "#app-vulns",
-	AppMinSeverity: "HIGH",
-}
+type Vulnerability = vuln.Vulnerability
 
-var severityLevels = map[string]int{
-	"LOW":      1,
-	"MEDIUM":   2,
-	"HIGH":     3,
-	"CRITICAL": 4,
-}
+var dispatcher *notify.Dispatcher
+var routes *router.Store
+var rtr *router.Router
+var silences *silence.Store
+var inhibitor *inhibit.Inhibitor
+var deduper *dedup.Deduper
+var republisher *ingest.Republisher
+var findings store.Store
+var auditLog *auth.AuditLog
 
 var vulnCounter = prometheus.NewCounterVec(
 	prometheus.CounterOpts{
@@ -58,167 +50,331 @@ var vulnCounter = prometheus.NewCounterVec(
 func main() {
 	prometheus.MustRegister(vulnCounter)
 
+	notifyCfg, err := notify.LoadConfig(envOr("NOTIFY_CONFIG", "notify.yaml"))
+	if err != nil {
+		log.Fatalf("Failed to load notify config: %v", err)
+	}
+	dispatcher = notify.NewDispatcher(notifyCfg)
+
+	findings, err = store.NewSQLite(envOr("FINDINGS_DB", "findings.db"))
+	if err != nil {
+		log.Fatalf("Failed to open findings store: %v", err)
+	}
+	dispatcher.OnDelivered = func(sink string, v vuln.Vulnerability, sendErr error) {
+		if sendErr != nil {
+			return
+		}
+		if err := findings.RecordNotification(context.Background(), vuln.Fingerprint(v), sink, time.Now()); err != nil {
+			log.Printf("Failed to record notification history: %v", err)
+		}
+	}
+
+	routesCfg, err := router.LoadConfig(envOr("ROUTES_CONFIG", "routes.yaml"))
+	if err != nil {
+		log.Fatalf("Failed to load routes config: %v", err)
+	}
+	routes = router.NewStore(routesCfg)
+	rtr = router.NewRouter(routes, dispatcher)
+
+	inhibitCfg, err := inhibit.LoadConfig(envOr("INHIBIT_CONFIG", "inhibit.yaml"))
+	if err != nil {
+		log.Fatalf("Failed to load inhibit config: %v", err)
+	}
+	inhibitor, err = inhibit.NewInhibitor(inhibitCfg.Rules)
+	if err != nil {
+		log.Fatalf("Failed to compile inhibit rules: %v", err)
+	}
+
+	silences = silence.NewStore()
+	deduper = dedup.NewDeduper(envDurationOr("DEDUP_REPEAT_INTERVAL", 10*time.Minute))
+
+	if topic := os.Getenv("REPUBLISH_TOPIC"); topic != "" {
+		republisher, err = ingest.NewRepublisher(context.Background(), os.Getenv("GCP_PROJECT"), topic)
+		if err != nil {
+			log.Fatalf("Failed to create republisher: %v", err)
+		}
+	}
+
+	auditLog, err = auth.NewAuditLog(context.Background(), envOr("AUDIT_LOG", "audit.log"), os.Getenv("GCP_PROJECT"), os.Getenv("AUDIT_TOPIC"))
+	if err != nil {
+		log.Fatalf("Failed to open audit log: %v", err)
+	}
+
 	go startWebServer()
-	go startPubSubListener()
+	go startPubSubIngester()
 
 	select {}
 }
 
+func envOr(key, fallback string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return fallback
+}
+
+// envDurationOr returns the time.Duration parsed from the named environment
+// variable, or fallback if it's unset or invalid.
+func envDurationOr(key string, fallback time.Duration) time.Duration {
+	v := os.Getenv(key)
+	if v == "" {
+		return fallback
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		log.Printf("Invalid duration for %s=%q, using default %s: %v", key, v, fallback, err)
+		return fallback
+	}
+	return d
+}
+
 func startWebServer() {
+	authCfg, err := auth.LoadConfig(envOr("AUTH_CONFIG", "auth.yaml"))
+	if err != nil {
+		log.Fatalf("Failed to load auth config: %v", err)
+	}
+	authMiddleware, err := auth.Middleware(authCfg)
+	if err != nil {
+		log.Fatalf("Failed to build auth middleware: %v", err)
+	}
+
 	r := gin.Default()
-	tmpl := template.Must(template.ParseFiles("config.html"))
-	r.GET("/config", func(c *gin.Context) {
-		config.Mutex.RLock()
-		defer config.Mutex.RUnlock()
-		tmpl.Execute(c.Writer, config)
+
+	// admin gates every config/silence/ack write behind both auth and
+	// CSRF. Basic auth's browser-cached credentials are replayed
+	// automatically on cross-origin requests, so auth alone isn't enough
+	// to stop a third-party page from forcing an authenticated operator's
+	// browser into an unwanted POST/DELETE; CSRF's double-submit cookie
+	// closes that gap. GET requests pass through CSRF unchecked (it only
+	// validates unsafe methods) so curl/CI callers reading /config or
+	// /silences are unaffected; callers that write still need to read the
+	// CSRF cookie/token from a prior GET first, the same as the dashboard
+	// does.
+	admin := r.Group("/", auth.CSRF(), authMiddleware)
+	admin.GET("/config", func(c *gin.Context) {
+		data, err := routes.YAML()
+		if err != nil {
+			c.String(http.StatusInternalServerError, "%v", err)
+			return
+		}
+		c.Data(http.StatusOK, "application/yaml", data)
+	})
+	admin.POST("/config", func(c *gin.Context) {
+		data, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			c.String(http.StatusBadRequest, "reading body: %v", err)
+			return
+		}
+		before, _ := routes.YAML()
+		if err := routes.SetFromYAML(data); err != nil {
+			c.String(http.StatusBadRequest, "%v", err)
+			return
+		}
+		audit(c, "config.update", diffYAML(before, data))
+		c.String(http.StatusOK, "routing config reloaded")
 	})
-	r.POST("/config", func(c *gin.Context) {
-		config.Mutex.Lock()
-		defer config.Mutex.Unlock()
-		config.OSChannelName = c.PostForm("os_channel")
-		config.OSMinSeverity = c.PostForm("os_severity")
-		config.AppChannelName = c.PostForm("app_channel")
-		config.AppMinSeverity = c.PostForm("app_severity")
-		c.Redirect(http.StatusSeeOther, "/config")
+	admin.GET("/silences", func(c *gin.Context) {
+		c.JSON(http.StatusOK, silences.List())
 	})
+	admin.POST("/silences", func(c *gin.Context) {
+		var sil silence.Silence
+		if err := c.ShouldBindJSON(&sil); err != nil {
+			c.String(http.StatusBadRequest, "%v", err)
+			return
+		}
+		created, err := silences.Create(&sil)
+		if err != nil {
+			c.String(http.StatusBadRequest, "%v", err)
+			return
+		}
+		audit(c, "silence.create", created.ID)
+		c.JSON(http.StatusCreated, created)
+	})
+	admin.DELETE("/silences/:id", func(c *gin.Context) {
+		if !silences.Delete(c.Param("id")) {
+			c.String(http.StatusNotFound, "silence %q not found", c.Param("id"))
+			return
+		}
+		audit(c, "silence.delete", c.Param("id"))
+		c.Status(http.StatusNoContent)
+	})
+	r.GET("/findings", func(c *gin.Context) {
+		filter := store.Filter{
+			Severity:     c.Query("severity"),
+			Type:         c.Query("type"),
+			PackageName:  c.Query("package_name"),
+			ResourceName: c.Query("resource_name"),
+			OnlyUnacked:  c.Query("unacked") == "true",
+			Limit:        queryIntOr(c, "limit", 50),
+			Offset:       queryIntOr(c, "offset", 0),
+		}
+		list, err := findings.List(c.Request.Context(), filter)
+		if err != nil {
+			c.String(http.StatusInternalServerError, "%v", err)
+			return
+		}
+		c.JSON(http.StatusOK, list)
+	})
+	admin.POST("/findings/:id/ack", func(c *gin.Context) {
+		id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+		if err != nil {
+			c.String(http.StatusBadRequest, "invalid finding id %q", c.Param("id"))
+			return
+		}
+		ackedBy := c.PostForm("acknowledged_by")
+		if err := findings.Ack(c.Request.Context(), id, ackedBy, time.Now()); err != nil {
+			c.String(http.StatusNotFound, "%v", err)
+			return
+		}
+		audit(c, "finding.ack", c.Param("id"))
+		c.Status(http.StatusOK)
+	})
+	r.GET("/dashboard", auth.CSRF(), serveDashboard)
 	r.GET("/metrics", gin.WrapH(promhttp.Handler()))
+
+	ingest.NewAlertmanagerWebhook().Register(r, ingested)
+	ingest.NewGeneric().Register(r, ingested)
+
 	r.Run(":8080")
 }
 
-func startPubSubListener() {
-	ctx := context.Background()
-	client, err := pubsub.NewClient(ctx, os.Getenv("GCP_PROJECT"))
-	if err != nil {
-		log.Fatalf("Failed to create pubsub client: %v", err)
-	}
-	sub := client.Subscription(os.Getenv("PUBSUB_SUBSCRIPTION"))
-	sub.Receive(ctx, func(ctx context.Context, msg *pubsub.Message) {
-		var v Vulnerability
-		if err := json.Unmarshal(msg.Data, &v); err != nil {
-			log.Printf("Invalid message format: %v", err)
-			msg.Nack()
-			return
-		}
-
-		vulnCounter.WithLabelValues(v.Severity, v.Type).Inc()
-		processVulnerability(v)
-		msg.Ack()
-	})
+// audit records who (per auth.Identity, "" when auth is disabled) did
+// what to the append-only audit log, logging rather than failing the
+// request if the write itself fails.
+func audit(c *gin.Context, action, detail string) {
+	event := auth.AuditEvent{Time: time.Now(), Actor: auth.Identity(c), Action: action, Detail: detail}
+	if err := auditLog.Record(c.Request.Context(), event); err != nil {
+		log.Printf("Failed to record audit event: %v", err)
+	}
 }
 
-func processVulnerability(v Vulnerability) {
-	config.Mutex.RLock()
-	defer config.Mutex.RUnlock()
+// diffYAML renders a minimal line-level diff between before and after, so
+// the audit log records which config fields actually changed rather than
+// just that a config.update happened. It compares the two documents as
+// multisets of lines, so line order is ignored: a line that only moved is
+// not reported, and each removed/added line is listed once per occurrence.
+func diffYAML(before, after []byte) string {
+	beforeLines := strings.Split(strings.TrimRight(string(before), "\n"), "\n")
+	afterLines := strings.Split(strings.TrimRight(string(after), "\n"), "\n")
 
-	severityRank := severityLevels[strings.ToUpper(v.Severity)]
+	var sb strings.Builder
+	removed := lineCounts(afterLines)
+	for _, l := range beforeLines {
+		if removed[l] > 0 {
+			removed[l]--
+			continue
+		}
+		fmt.Fprintf(&sb, "-%s\n", l)
+	}
+	added := lineCounts(beforeLines)
+	for _, l := range afterLines {
+		if added[l] > 0 {
+			added[l]--
+			continue
+		}
+		fmt.Fprintf(&sb, "+%s\n", l)
+	}
+	return strings.TrimRight(sb.String(), "\n")
+}
 
-	if v.Type == "OS" && severityRank >= severityLevels[config.OSMinSeverity] {
-		sendToSlack(config.OSChannelName, v)
-	} else if v.Type == "APP" && severityRank >= severityLevels[config.AppMinSeverity] {
-		sendToSlack(config.AppChannelName, v)
+func lineCounts(lines []string) map[string]int {
+	counts := make(map[string]int, len(lines))
+	for _, l := range lines {
+		counts[l]++
 	}
+	return counts
 }
 
-/* This function sends a formatted message to a Slack channel.
-func sendToSlack(channel string, v Vulnerability) {
-	message := map[string]interface{}{
-		"channel": channel,
-		"blocks": []map[string]interface{}{
-			{
-				"type": "section",
-				"text": map[string]string{
-					"type": "mrkdwn",
-					"text": fmt.Sprintf(
-						"*Vulnerability Alert*\n*Severity:* `%s`\n*Type:* `%s`\n*Package:* `%s`\n*Resource:* `%s`\n*Description:* %s",
-						v.Severity,
-						v.Type,
-						v.PackageName,
-						v.ResourceName,
-						v.Description,
-					),
-				},
-			},
-		},
-	}
-
-	body, err := json.Marshal(message)
+// queryIntOr parses the named query parameter as an int, returning
+// fallback if it's absent or invalid.
+func queryIntOr(c *gin.Context, key string, fallback int) int {
+	v := c.Query(key)
+	if v == "" {
+		return fallback
+	}
+	n, err := strconv.Atoi(v)
 	if err != nil {
-		log.Printf("Failed to marshal Slack payload: %v", err)
-		return
+		return fallback
 	}
+	return n
+}
 
-	resp, err := http.Post(os.Getenv("SLACK_WEBHOOK"), "application/json", strings.NewReader(string(body)))
+// dashboardData is what dashboard.html renders.
+type dashboardData struct {
+	Summary   *store.Summary
+	Findings  []*store.Finding
+	CSRFToken string
+}
+
+var dashboardTmpl = template.Must(template.ParseFiles("dashboard.html"))
+
+// serveDashboard renders the findings dashboard: severity counts, top
+// offending packages/resources, and a table of unacknowledged findings.
+func serveDashboard(c *gin.Context) {
+	summary, err := findings.Summary(c.Request.Context())
+	if err != nil {
+		c.String(http.StatusInternalServerError, "%v", err)
+		return
+	}
+	active, err := findings.List(c.Request.Context(), store.Filter{OnlyUnacked: true, Limit: 200})
 	if err != nil {
-		log.Printf("Failed to send Slack notification: %v", err)
+		c.String(http.StatusInternalServerError, "%v", err)
 		return
 	}
-	defer resp.Body.Close()
+	dashboardTmpl.Execute(c.Writer, dashboardData{Summary: summary, Findings: active, CSRFToken: auth.CSRFToken(c)})
+}
 
-	if resp.StatusCode >= 300 {
-		log.Printf("Slack returned non-200 status: %s", resp.Status)
+// startPubSubIngester runs the GCP Pub/Sub pull ingester, p2s's original
+// (and still default) input source, until it returns an unrecoverable
+// error.
+func startPubSubIngester() {
+	pubsubIngester := ingest.NewPubSub(os.Getenv("GCP_PROJECT"), os.Getenv("PUBSUB_SUBSCRIPTION"))
+	if err := pubsubIngester.Run(context.Background(), ingested); err != nil {
+		log.Fatalf("pubsub ingester stopped: %v", err)
 	}
 }
-*/
-
-// This function sends a formatted message to a Slack channel with a link to the GCP Security Command Center.
-
-func sendToSlack(channel string, v Vulnerability) {
-	projectID := os.Getenv("GCP_PROJECT")
-
-	// Construct a GCP Security Command Center console URL (adjust format if needed)
-	sccLink := fmt.Sprintf(
-		"https://console.cloud.google.com/security/command-center/findings?project=%s&resourceName=%s",
-		projectID,
-		url.QueryEscape(v.ResourceName),
-	)
-
-	message := map[string]interface{}{
-		"channel": channel,
-		"blocks": []map[string]interface{}{
-			{
-				"type": "section",
-				"text": map[string]string{
-					"type": "mrkdwn",
-					"text": fmt.Sprintf(
-						"*Vulnerability Alert*\n*Severity:* `%s`\n*Type:* `%s`\n*Package:* `%s`\n*Resource:* `%s`\n*Description:* %s",
-						v.Severity,
-						v.Type,
-						v.PackageName,
-						v.ResourceName,
-						v.Description,
-					),
-				},
-			},
-			{
-				"type": "actions",
-				"elements": []map[string]interface{}{
-					{
-						"type": "button",
-						"text": map[string]string{
-							"type": "plain_text",
-							"text": "View in GCP SCC",
-						},
-						"url": sccLink,
-					},
-				},
-			},
-		},
-	}
-
-	body, err := json.Marshal(message)
-	if err != nil {
-		log.Printf("Failed to marshal Slack payload: %v", err)
-		return
+
+// ingested is the single entry point every Ingester calls with a parsed
+// Vulnerability, whatever its transport. In bridge mode it republishes to
+// the outbound Pub/Sub topic instead of routing locally; otherwise it
+// feeds the normal silence/inhibit/dedup/route pipeline.
+func ingested(v Vulnerability) {
+	vulnCounter.WithLabelValues(v.Severity, v.Type).Inc()
+
+	if _, err := findings.Record(context.Background(), v, time.Now()); err != nil {
+		log.Printf("Failed to record finding: %v", err)
 	}
 
-	resp, err := http.Post(os.Getenv("SLACK_WEBHOOK"), "application/json", strings.NewReader(string(body)))
-	if err != nil {
-		log.Printf("Failed to send Slack notification: %v", err)
+	if republisher != nil {
+		if err := republisher.Publish(context.Background(), v); err != nil {
+			log.Printf("Failed to republish vulnerability: %v", err)
+		}
 		return
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode >= 300 {
-		log.Printf("Slack returned non-200 status: %s", resp.Status)
+	processVulnerability(v)
+}
+
+// processVulnerability consults silencing, inhibition, and dedup before
+// routing v through the live routing tree, which dispatches it to every
+// receiver's sinks via the notify package.
+func processVulnerability(v Vulnerability) {
+	now := time.Now()
+	labels := router.Labels(v)
+
+	inhibitor.Observe(labels, now)
+
+	if _, muted := silences.Active(labels, now); muted {
+		silence.RecordSilenced()
+		return
+	}
+	if inhibitor.Inhibited(labels, now) {
+		inhibit.RecordInhibited()
+		return
 	}
+	if !deduper.Allow(v, now) {
+		return
+	}
+
+	rtr.Route(v)
 }
@@ -0,0 +1,52 @@
+package main
+
+import "testing"
+
+func TestDiffYAML(t *testing.T) {
+	cases := []struct {
+		name   string
+		before string
+		after  string
+		want   string
+	}{
+		{
+			name:   "no change",
+			before: "a\nb\nc\n",
+			after:  "a\nb\nc\n",
+			want:   "",
+		},
+		{
+			name:   "line added",
+			before: "a\nb\n",
+			after:  "a\nb\nc\n",
+			want:   "+c",
+		},
+		{
+			name:   "line removed",
+			before: "a\nb\nc\n",
+			after:  "a\nb\n",
+			want:   "-c",
+		},
+		{
+			name:   "line changed",
+			before: "a\nb\nc\n",
+			after:  "a\nx\nc\n",
+			want:   "-b\n+x",
+		},
+		{
+			name:   "lines reordered, no content change",
+			before: "a\nb\nc\n",
+			after:  "c\nb\na\n",
+			want:   "",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got := diffYAML([]byte(c.before), []byte(c.after))
+			if got != c.want {
+				t.Errorf("diffYAML(%q, %q) = %q, want %q", c.before, c.after, got, c.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,59 @@
+// Package matcher compiles and evaluates Alertmanager-style label matchers
+// (exact `match` plus regex `match_re` maps), shared by any subsystem that
+// needs to test a Vulnerability's label set against operator-configured
+// rules (routing, silencing, inhibition).
+package matcher
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// Matcher is a single compiled match/match_re entry.
+type Matcher struct {
+	Label string
+	Value string
+	Re    *regexp.Regexp // nil for exact-match entries
+}
+
+// Matches reports whether labels satisfies this matcher.
+func (m Matcher) Matches(labels map[string]string) bool {
+	got, ok := labels[m.Label]
+	if !ok {
+		return false
+	}
+	if m.Re != nil {
+		return m.Re.MatchString(got)
+	}
+	return got == m.Value
+}
+
+// Compile compiles match/match_re maps into a flat list of matchers that
+// must ALL hold for their owner (a route, silence, or inhibit rule) to
+// match.
+func Compile(match, matchRe map[string]string) ([]Matcher, error) {
+	matchers := make([]Matcher, 0, len(match)+len(matchRe))
+	for label, value := range match {
+		matchers = append(matchers, Matcher{Label: label, Value: value})
+	}
+	for label, pattern := range matchRe {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid match_re for label %q: %w", label, err)
+		}
+		matchers = append(matchers, Matcher{Label: label, Re: re})
+	}
+	return matchers, nil
+}
+
+// MatchesAll reports whether labels satisfies every matcher. No matchers
+// always matches, which lets a rule with an empty match/match_re act as a
+// catch-all.
+func MatchesAll(matchers []Matcher, labels map[string]string) bool {
+	for _, m := range matchers {
+		if !m.Matches(labels) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,38 @@
+package matcher
+
+import "testing"
+
+func TestMatchesAll(t *testing.T) {
+	matchers, err := Compile(
+		map[string]string{"type": "OS"},
+		map[string]string{"severity": "HIGH|CRITICAL"},
+	)
+	if err != nil {
+		t.Fatalf("Compile: %v", err)
+	}
+
+	cases := []struct {
+		name   string
+		labels map[string]string
+		want   bool
+	}{
+		{"all match", map[string]string{"type": "OS", "severity": "CRITICAL"}, true},
+		{"regex miss", map[string]string{"type": "OS", "severity": "LOW"}, false},
+		{"exact miss", map[string]string{"type": "APP", "severity": "HIGH"}, false},
+		{"missing label", map[string]string{"type": "OS"}, false},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := MatchesAll(matchers, c.labels); got != c.want {
+				t.Errorf("MatchesAll(%v) = %v, want %v", c.labels, got, c.want)
+			}
+		})
+	}
+}
+
+func TestCompileInvalidRegex(t *testing.T) {
+	if _, err := Compile(nil, map[string]string{"severity": "("}); err == nil {
+		t.Error("expected error compiling an invalid match_re pattern")
+	}
+}
@@ -0,0 +1,43 @@
+package notify
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+var errNoSinkConfigured = errors.New("sink has no notifier config block")
+
+// Config is the top-level notify configuration, loaded from YAML in the
+// style of tenderduty: a named map of sinks, each independently enabled and
+// holding its own connection settings.
+type Config struct {
+	Sinks map[string]SinkConfig `yaml:"sinks"`
+}
+
+// SinkConfig holds the settings for exactly one of the supported notifier
+// types. Exactly one of the pointer fields should be set; Enabled lets an
+// operator keep the block around but switch it off without deleting it.
+type SinkConfig struct {
+	Enabled  bool            `yaml:"enabled"`
+	Slack    *SlackConfig    `yaml:"slack,omitempty"`
+	Discord  *DiscordConfig  `yaml:"discord,omitempty"`
+	Telegram *TelegramConfig `yaml:"telegram,omitempty"`
+	Teams    *TeamsConfig    `yaml:"teams,omitempty"`
+	Webhook  *WebhookConfig  `yaml:"webhook,omitempty"`
+}
+
+// LoadConfig reads and parses a notify Config from path.
+func LoadConfig(path string) (Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Config{}, fmt.Errorf("notify: reading config: %w", err)
+	}
+	var cfg Config
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return Config{}, fmt.Errorf("notify: parsing config: %w", err)
+	}
+	return cfg, nil
+}
@@ -0,0 +1,79 @@
+package notify
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/alexfun1/p2s/vuln"
+)
+
+// DiscordConfig configures the Discord sink. The embed fields mirror the
+// shape used by the Prometheus Operator's discord receiver.
+type DiscordConfig struct {
+	WebhookURL string `yaml:"webhook_url"`
+}
+
+type discordNotifier struct {
+	name string
+	cfg  DiscordConfig
+}
+
+func newDiscordNotifier(name string, cfg DiscordConfig) *discordNotifier {
+	return &discordNotifier{name: name, cfg: cfg}
+}
+
+func (d *discordNotifier) Name() string { return d.name }
+
+// Send posts v as a Discord embed via the configured webhook.
+func (d *discordNotifier) Send(v vuln.Vulnerability) error {
+	color := discordColorForSeverity(v.Severity)
+
+	message := map[string]interface{}{
+		"embeds": []map[string]interface{}{
+			{
+				"title": "Vulnerability Alert",
+				"color": color,
+				"fields": []map[string]interface{}{
+					{"name": "Severity", "value": v.Severity, "inline": true},
+					{"name": "Type", "value": v.Type, "inline": true},
+					{"name": "Package", "value": v.PackageName, "inline": true},
+					{"name": "Resource", "value": v.ResourceName, "inline": false},
+					{"name": "Description", "value": v.Description, "inline": false},
+				},
+			},
+		},
+	}
+
+	body, err := json.Marshal(message)
+	if err != nil {
+		return fmt.Errorf("marshal discord payload: %w", err)
+	}
+
+	resp, err := http.Post(d.cfg.WebhookURL, "application/json", strings.NewReader(string(body)))
+	if err != nil {
+		return fmt.Errorf("post to discord: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("discord returned non-2xx status: %s", resp.Status)
+	}
+	return nil
+}
+
+// discordColorForSeverity maps a severity to the decimal RGB value Discord
+// embeds expect.
+func discordColorForSeverity(severity string) int {
+	switch severity {
+	case "CRITICAL":
+		return 0xE01E5A
+	case "HIGH":
+		return 0xFF8C00
+	case "MEDIUM":
+		return 0xECB22E
+	default:
+		return 0x2EB67D
+	}
+}
@@ -0,0 +1,17 @@
+package notify
+
+import "github.com/prometheus/client_golang/prometheus"
+
+// deliveryTotal tracks per-sink delivery outcomes so /metrics can surface
+// notifier health (e.g. alert on a Discord webhook that's been failing).
+var deliveryTotal = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "notify_delivery_total",
+		Help: "Total notification delivery attempts by sink and outcome (success/failure)",
+	},
+	[]string{"sink", "outcome"},
+)
+
+func init() {
+	prometheus.MustRegister(deliveryTotal)
+}
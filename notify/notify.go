@@ -0,0 +1,106 @@
+// Package notify dispatches vulnerability findings to one or more chat/
+// webhook sinks (Slack, Discord, Telegram, MS Teams, generic webhooks).
+package notify
+
+import (
+	"log"
+	"time"
+
+	"github.com/alexfun1/p2s/vuln"
+)
+
+// Notifier delivers a single Vulnerability to a destination. Implementations
+// must be safe for concurrent use.
+type Notifier interface {
+	// Name identifies the sink in logs and Prometheus labels.
+	Name() string
+	// Send delivers v, returning an error if delivery failed.
+	Send(v vuln.Vulnerability) error
+}
+
+// Dispatcher fans a Vulnerability out to a caller-chosen list of named
+// sinks, retrying each one independently.
+type Dispatcher struct {
+	cfg Config
+
+	// OnDelivered, if set, is called once per sink after delivery finishes,
+	// retries included (successful or not), so callers can record delivery history
+	// elsewhere (e.g. a findings store's notification_history).
+	OnDelivered func(sink string, v vuln.Vulnerability, err error)
+}
+
+// NewDispatcher builds a Dispatcher from cfg. Sink names are resolved
+// against cfg.Sinks only when SendTo is called, so an unknown or disabled
+// sink never causes a startup failure.
+func NewDispatcher(cfg Config) *Dispatcher {
+	return &Dispatcher{cfg: cfg}
+}
+
+// SendTo dispatches v to each named sink in sinkNames, silently skipping any
+// that are unknown or disabled in the configuration and skipping with a log
+// line any that have no notifier config block.
+func (d *Dispatcher) SendTo(sinkNames []string, v vuln.Vulnerability) {
+	for _, sinkName := range sinkNames {
+		sink, ok := d.cfg.Sinks[sinkName]
+		if !ok || !sink.Enabled {
+			continue
+		}
+		n, err := buildNotifier(sinkName, sink)
+		if err != nil {
+			log.Printf("notify: skipping sink %q: %v", sinkName, err)
+			continue
+		}
+		go d.deliver(n, v)
+	}
+}
+
+// deliver sends v via n, retrying with backoff, recording the outcome in
+// the notify_delivery_total Prometheus counter, and notifying OnDelivered if set.
+func (d *Dispatcher) deliver(n Notifier, v vuln.Vulnerability) {
+	err := withRetry(3, 500*time.Millisecond, func() error {
+		return n.Send(v)
+	})
+	if err != nil {
+		deliveryTotal.WithLabelValues(n.Name(), "failure").Inc()
+		log.Printf("notify: %s delivery failed: %v", n.Name(), err)
+	} else {
+		deliveryTotal.WithLabelValues(n.Name(), "success").Inc()
+	}
+	if d.OnDelivered != nil {
+		d.OnDelivered(n.Name(), v, err)
+	}
+}
+
+// withRetry calls fn up to attempts times, doubling backoff between
+// failures, and returns the last error if every attempt failed.
+func withRetry(attempts int, backoff time.Duration, fn func() error) error {
+	var err error
+	for i := 0; i < attempts; i++ {
+		if err = fn(); err == nil {
+			return nil
+		}
+		if i < attempts-1 {
+			time.Sleep(backoff)
+			backoff *= 2
+		}
+	}
+	return err
+}
+
+// buildNotifier constructs the concrete Notifier for a sink configuration.
+func buildNotifier(name string, sink SinkConfig) (Notifier, error) {
+	switch {
+	case sink.Slack != nil:
+		return newSlackNotifier(name, *sink.Slack), nil
+	case sink.Discord != nil:
+		return newDiscordNotifier(name, *sink.Discord), nil
+	case sink.Telegram != nil:
+		return newTelegramNotifier(name, *sink.Telegram), nil
+	case sink.Teams != nil:
+		return newTeamsNotifier(name, *sink.Teams), nil
+	case sink.Webhook != nil:
+		return newWebhookNotifier(name, *sink.Webhook), nil
+	default:
+		return nil, errNoSinkConfigured
+	}
+}
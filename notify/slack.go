@@ -0,0 +1,88 @@
+package notify
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/alexfun1/p2s/vuln"
+)
+
+// SlackConfig configures the Slack sink.
+type SlackConfig struct {
+	WebhookURL string `yaml:"webhook_url"`
+	Channel    string `yaml:"channel"`
+	GCPProject string `yaml:"gcp_project"`
+}
+
+type slackNotifier struct {
+	name string
+	cfg  SlackConfig
+}
+
+func newSlackNotifier(name string, cfg SlackConfig) *slackNotifier {
+	return &slackNotifier{name: name, cfg: cfg}
+}
+
+func (s *slackNotifier) Name() string { return s.name }
+
+// Send posts a formatted message to the configured Slack channel, with a
+// link back to the GCP Security Command Center finding.
+func (s *slackNotifier) Send(v vuln.Vulnerability) error {
+	sccLink := fmt.Sprintf(
+		"https://console.cloud.google.com/security/command-center/findings?project=%s&resourceName=%s",
+		s.cfg.GCPProject,
+		url.QueryEscape(v.ResourceName),
+	)
+
+	message := map[string]interface{}{
+		"channel": s.cfg.Channel,
+		"blocks": []map[string]interface{}{
+			{
+				"type": "section",
+				"text": map[string]string{
+					"type": "mrkdwn",
+					"text": fmt.Sprintf(
+						"*Vulnerability Alert*\n*Severity:* `%s`\n*Type:* `%s`\n*Package:* `%s`\n*Resource:* `%s`\n*Description:* %s",
+						v.Severity,
+						v.Type,
+						v.PackageName,
+						v.ResourceName,
+						v.Description,
+					),
+				},
+			},
+			{
+				"type": "actions",
+				"elements": []map[string]interface{}{
+					{
+						"type": "button",
+						"text": map[string]string{
+							"type": "plain_text",
+							"text": "View in GCP SCC",
+						},
+						"url": sccLink,
+					},
+				},
+			},
+		},
+	}
+
+	body, err := json.Marshal(message)
+	if err != nil {
+		return fmt.Errorf("marshal slack payload: %w", err)
+	}
+
+	resp, err := http.Post(s.cfg.WebhookURL, "application/json", strings.NewReader(string(body)))
+	if err != nil {
+		return fmt.Errorf("post to slack: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("slack returned non-2xx status: %s", resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package notify
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/alexfun1/p2s/vuln"
+)
+
+// TeamsConfig configures the Microsoft Teams sink.
+type TeamsConfig struct {
+	WebhookURL string `yaml:"webhook_url"`
+}
+
+type teamsNotifier struct {
+	name string
+	cfg  TeamsConfig
+}
+
+func newTeamsNotifier(name string, cfg TeamsConfig) *teamsNotifier {
+	return &teamsNotifier{name: name, cfg: cfg}
+}
+
+func (t *teamsNotifier) Name() string { return t.name }
+
+// Send posts v as an Office 365 connector card to the configured Teams
+// webhook.
+func (t *teamsNotifier) Send(v vuln.Vulnerability) error {
+	message := map[string]interface{}{
+		"@type":    "MessageCard",
+		"@context": "http://schema.org/extensions",
+		"summary":  "Vulnerability Alert",
+		"title":    "Vulnerability Alert",
+		"sections": []map[string]interface{}{
+			{
+				"facts": []map[string]string{
+					{"name": "Severity", "value": v.Severity},
+					{"name": "Type", "value": v.Type},
+					{"name": "Package", "value": v.PackageName},
+					{"name": "Resource", "value": v.ResourceName},
+				},
+				"text": v.Description,
+			},
+		},
+	}
+
+	body, err := json.Marshal(message)
+	if err != nil {
+		return fmt.Errorf("marshal teams payload: %w", err)
+	}
+
+	resp, err := http.Post(t.cfg.WebhookURL, "application/json", strings.NewReader(string(body)))
+	if err != nil {
+		return fmt.Errorf("post to teams: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("teams returned non-2xx status: %s", resp.Status)
+	}
+	return nil
+}
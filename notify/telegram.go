@@ -0,0 +1,59 @@
+package notify
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/alexfun1/p2s/vuln"
+)
+
+// TelegramConfig configures the Telegram sink: a bot token plus the chat ID
+// to post into.
+type TelegramConfig struct {
+	BotToken string `yaml:"bot_token"`
+	ChatID   string `yaml:"chat_id"`
+}
+
+type telegramNotifier struct {
+	name string
+	cfg  TelegramConfig
+}
+
+func newTelegramNotifier(name string, cfg TelegramConfig) *telegramNotifier {
+	return &telegramNotifier{name: name, cfg: cfg}
+}
+
+func (t *telegramNotifier) Name() string { return t.name }
+
+// Send posts v as a Markdown message via the Telegram Bot API.
+func (t *telegramNotifier) Send(v vuln.Vulnerability) error {
+	text := fmt.Sprintf(
+		"*Vulnerability Alert*\n*Severity:* `%s`\n*Type:* `%s`\n*Package:* `%s`\n*Resource:* `%s`\n*Description:* %s",
+		v.Severity, v.Type, v.PackageName, v.ResourceName, v.Description,
+	)
+
+	message := map[string]interface{}{
+		"chat_id":    t.cfg.ChatID,
+		"text":       text,
+		"parse_mode": "Markdown",
+	}
+
+	body, err := json.Marshal(message)
+	if err != nil {
+		return fmt.Errorf("marshal telegram payload: %w", err)
+	}
+
+	apiURL := fmt.Sprintf("https://api.telegram.org/bot%s/sendMessage", t.cfg.BotToken)
+	resp, err := http.Post(apiURL, "application/json", strings.NewReader(string(body)))
+	if err != nil {
+		return fmt.Errorf("post to telegram: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("telegram returned non-2xx status: %s", resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+package notify
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/alexfun1/p2s/vuln"
+)
+
+// WebhookConfig configures a generic JSON webhook sink, for operators who
+// want to wire p2s into something shoutrrr or a custom service supports
+// that isn't one of the named sinks above.
+type WebhookConfig struct {
+	URL     string            `yaml:"url"`
+	Headers map[string]string `yaml:"headers"`
+}
+
+type webhookNotifier struct {
+	name string
+	cfg  WebhookConfig
+}
+
+func newWebhookNotifier(name string, cfg WebhookConfig) *webhookNotifier {
+	return &webhookNotifier{name: name, cfg: cfg}
+}
+
+func (w *webhookNotifier) Name() string { return w.name }
+
+// Send POSTs the Vulnerability as-is (no reformatting) to the configured
+// URL, with any extra headers attached.
+func (w *webhookNotifier) Send(v vuln.Vulnerability) error {
+	body, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("marshal webhook payload: %w", err)
+	}
+
+	req, err := http.NewRequest(http.MethodPost, w.cfg.URL, strings.NewReader(string(body)))
+	if err != nil {
+		return fmt.Errorf("build webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range w.cfg.Headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("post to webhook: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned non-2xx status: %s", resp.Status)
+	}
+	return nil
+}
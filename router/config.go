@@ -0,0 +1,94 @@
+package router
+
+import (
+	"fmt"
+	"os"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Config is the full routing configuration: the root of the routing tree
+// plus the receivers its leaves can point into.
+type Config struct {
+	Route     *Route              `yaml:"route" json:"route"`
+	Receivers map[string]Receiver `yaml:"receivers" json:"receivers"`
+}
+
+// Receiver maps a routing tree leaf to the notify sinks that should fire
+// when a finding reaches it.
+type Receiver struct {
+	Sinks []string `yaml:"sinks" json:"sinks"`
+}
+
+// Compile validates and compiles every route's matchers. It must be called
+// once before a Config is used to route anything.
+func (c *Config) Compile() error {
+	if c.Route == nil {
+		return fmt.Errorf("router: config has no root route")
+	}
+	return c.Route.Compile()
+}
+
+// LoadConfig reads and compiles a routing Config from a YAML file.
+func LoadConfig(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("router: reading config: %w", err)
+	}
+	var cfg Config
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("router: parsing config: %w", err)
+	}
+	if err := cfg.Compile(); err != nil {
+		return nil, err
+	}
+	return &cfg, nil
+}
+
+// Store holds the live routing Config behind a mutex so the /config
+// endpoint can hot-reload it without restarting the process.
+type Store struct {
+	mu  sync.RWMutex
+	cfg *Config
+}
+
+// NewStore wraps an already-compiled Config for live access.
+func NewStore(cfg *Config) *Store {
+	return &Store{cfg: cfg}
+}
+
+// Get returns the currently active Config.
+func (s *Store) Get() *Config {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.cfg
+}
+
+// Set compiles cfg and, only if that succeeds, replaces the live config -
+// so a bad edit submitted to /config never takes effect.
+func (s *Store) Set(cfg *Config) error {
+	if err := cfg.Compile(); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.cfg = cfg
+	return nil
+}
+
+// SetFromYAML parses and installs a new Config from raw YAML, as submitted
+// by the /config endpoint.
+func (s *Store) SetFromYAML(data []byte) error {
+	var cfg Config
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return fmt.Errorf("router: parsing config: %w", err)
+	}
+	return s.Set(&cfg)
+}
+
+// YAML renders the live Config back to YAML, for display/editing at
+// /config.
+func (s *Store) YAML() ([]byte, error) {
+	return yaml.Marshal(s.Get())
+}
@@ -0,0 +1,138 @@
+package router
+
+import (
+	"sync"
+	"time"
+
+	"github.com/alexfun1/p2s/vuln"
+)
+
+const (
+	defaultGroupWait      = 30 * time.Second
+	defaultGroupInterval  = 5 * time.Minute
+	defaultRepeatInterval = 4 * time.Hour
+
+	// staleAfterRepeats bounds how many repeat_interval ticks a group may
+	// sit idle (no new findings) before it's dropped. Without this, a
+	// group's timer re-arms itself forever even after the underlying
+	// finding stops recurring, leaking one goroutine/timer per
+	// receiver+group_by key ever seen over the life of a long-running
+	// instance.
+	staleAfterRepeats = 4
+)
+
+// group holds the pending and last-delivered findings for one receiver+key
+// combination.
+type group struct {
+	pending      []vuln.Vulnerability
+	lastSent     []vuln.Vulnerability
+	sentAt       time.Time
+	lastActivity time.Time
+	timer        *time.Timer
+}
+
+// Grouper coalesces vulnerabilities routed to the same receiver (and group
+// key) into a single flush per group_wait/group_interval window, and optionally
+// re-sends the last batch every repeat_interval if nothing new arrived.
+// This is what keeps a burst of redelivered Pub/Sub messages for the same
+// package+resource from paging a channel once per message.
+type Grouper struct {
+	mu     sync.Mutex
+	groups map[string]*group
+	Flush  func(route *Route, key string, findings []vuln.Vulnerability)
+}
+
+// NewGrouper builds a Grouper that calls flush once a group's window
+// elapses, with whatever new findings (or, on a repeat_interval tick with
+// nothing new, the last batch) arrived in that window.
+func NewGrouper(flush func(route *Route, key string, findings []vuln.Vulnerability)) *Grouper {
+	return &Grouper{groups: make(map[string]*group), Flush: flush}
+}
+
+// Add enqueues v for route under the given group key (see GroupKey),
+// starting that group's group_wait timer if this is the first finding
+// seen for it.
+func (g *Grouper) Add(route *Route, key string, v vuln.Vulnerability) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	id := route.Receiver + "\x00" + key
+	gr, ok := g.groups[id]
+	if !ok {
+		gr = &group{}
+		g.groups[id] = gr
+		gr.timer = time.AfterFunc(nonZero(route.GroupWait.Duration(), defaultGroupWait), func() {
+			g.flush(route, key, id)
+		})
+	}
+	gr.pending = append(gr.pending, v)
+	gr.lastActivity = time.Now()
+}
+
+func (g *Grouper) flush(route *Route, key, id string) {
+	g.mu.Lock()
+	gr, ok := g.groups[id]
+	if !ok {
+		g.mu.Unlock()
+		return
+	}
+
+	findings := gr.pending
+	gr.pending = nil
+
+	repeat := nonZero(route.RepeatInterval.Duration(), defaultRepeatInterval)
+	resend := len(findings) == 0 && !gr.sentAt.IsZero() && time.Since(gr.sentAt) >= repeat
+
+	var toSend []vuln.Vulnerability
+	switch {
+	case len(findings) > 0:
+		toSend = findings
+		gr.lastSent = findings
+		gr.sentAt = time.Now()
+	case resend:
+		toSend = gr.lastSent
+		gr.sentAt = time.Now()
+	}
+
+	// Nothing new has arrived in a long while: assume the underlying
+	// finding resolved and drop the group instead of re-arming its timer
+	// forever. Add recreates the group from scratch if it recurs.
+	if len(findings) == 0 && time.Since(gr.lastActivity) >= repeat*staleAfterRepeats {
+		delete(g.groups, id)
+		g.mu.Unlock()
+		if len(toSend) > 0 {
+			g.Flush(route, key, toSend)
+		}
+		return
+	}
+
+	gr.timer = time.AfterFunc(nonZero(route.GroupInterval.Duration(), defaultGroupInterval), func() {
+		g.flush(route, key, id)
+	})
+	g.mu.Unlock()
+
+	if len(toSend) > 0 {
+		g.Flush(route, key, toSend)
+	}
+}
+
+func nonZero(d, fallback time.Duration) time.Duration {
+	if d <= 0 {
+		return fallback
+	}
+	return d
+}
+
+// GroupKey computes the key used to coalesce findings routed to route: the
+// values of its group_by labels, joined in order. Findings whose group_by
+// values all match share one notification.
+func GroupKey(route *Route, labels map[string]string) string {
+	if len(route.GroupBy) == 0 {
+		return ""
+	}
+	key := ""
+	for _, label := range route.GroupBy {
+		key += label + "=" + labels[label] + "\x00"
+	}
+	return key
+}
@@ -0,0 +1,107 @@
+package router
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alexfun1/p2s/vuln"
+)
+
+// flushRecorder collects Grouper flushes in arrival order, safe for the
+// concurrent timer goroutines that drive them.
+type flushRecorder struct {
+	mu     sync.Mutex
+	batches [][]vuln.Vulnerability
+}
+
+func (r *flushRecorder) record(_ *Route, _ string, findings []vuln.Vulnerability) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.batches = append(r.batches, findings)
+}
+
+func (r *flushRecorder) len() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.batches)
+}
+
+func (r *flushRecorder) last() []vuln.Vulnerability {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.batches[len(r.batches)-1]
+}
+
+func waitFor(t *testing.T, timeout time.Duration, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline) {
+		if cond() {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatalf("condition not met within %s", timeout)
+}
+
+func TestGrouperFlushesAfterGroupWait(t *testing.T) {
+	rec := &flushRecorder{}
+	g := NewGrouper(rec.record)
+	route := &Route{Receiver: "os-team", GroupWait: Duration(20 * time.Millisecond), GroupInterval: Duration(time.Hour), RepeatInterval: Duration(time.Hour)}
+
+	g.Add(route, "key", vuln.Vulnerability{PackageName: "openssl"})
+	g.Add(route, "key", vuln.Vulnerability{PackageName: "openssl"})
+
+	if rec.len() != 0 {
+		t.Fatalf("flushed before group_wait elapsed")
+	}
+	waitFor(t, time.Second, func() bool { return rec.len() == 1 })
+	if len(rec.last()) != 2 {
+		t.Errorf("first flush carried %d findings, want 2 (coalesced)", len(rec.last()))
+	}
+}
+
+func TestGrouperResendsOnRepeatInterval(t *testing.T) {
+	rec := &flushRecorder{}
+	g := NewGrouper(rec.record)
+	route := &Route{
+		Receiver:       "os-team",
+		GroupWait:      Duration(10 * time.Millisecond),
+		GroupInterval:  Duration(20 * time.Millisecond),
+		RepeatInterval: Duration(40 * time.Millisecond),
+	}
+
+	g.Add(route, "key", vuln.Vulnerability{PackageName: "openssl"})
+	waitFor(t, time.Second, func() bool { return rec.len() == 1 })
+
+	// No new findings arrive, but once repeat_interval elapses the last
+	// batch should be resent rather than silently dropped.
+	waitFor(t, time.Second, func() bool { return rec.len() == 2 })
+	if len(rec.last()) != 1 || rec.last()[0].PackageName != "openssl" {
+		t.Errorf("resend batch = %v, want the last-sent finding", rec.last())
+	}
+}
+
+func TestGrouperDropsStaleGroupAfterRepeatsWithNoActivity(t *testing.T) {
+	rec := &flushRecorder{}
+	g := NewGrouper(rec.record)
+	route := &Route{
+		Receiver:       "os-team",
+		GroupWait:      Duration(5 * time.Millisecond),
+		GroupInterval:  Duration(5 * time.Millisecond),
+		RepeatInterval: Duration(5 * time.Millisecond),
+	}
+
+	g.Add(route, "key", vuln.Vulnerability{PackageName: "openssl"})
+	id := route.Receiver + "\x00key"
+
+	// staleAfterRepeats repeat_intervals of silence should drop the group
+	// instead of leaving its timer re-arming forever.
+	waitFor(t, time.Second, func() bool {
+		g.mu.Lock()
+		_, ok := g.groups[id]
+		g.mu.Unlock()
+		return !ok
+	})
+}
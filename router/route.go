@@ -0,0 +1,58 @@
+package router
+
+import "github.com/alexfun1/p2s/matcher"
+
+// Route is one node of an Alertmanager-style routing tree: a set of label
+// matchers, the receiver to notify when they hold, and nested sub-routes
+// to refine further.
+type Route struct {
+	Match          map[string]string `yaml:"match,omitempty" json:"match,omitempty"`
+	MatchRe        map[string]string `yaml:"match_re,omitempty" json:"match_re,omitempty"`
+	Receiver       string            `yaml:"receiver,omitempty" json:"receiver,omitempty"`
+	Continue       bool              `yaml:"continue,omitempty" json:"continue,omitempty"`
+	GroupBy        []string          `yaml:"group_by,omitempty" json:"group_by,omitempty"`
+	GroupWait      Duration          `yaml:"group_wait,omitempty" json:"group_wait,omitempty"`
+	GroupInterval  Duration          `yaml:"group_interval,omitempty" json:"group_interval,omitempty"`
+	RepeatInterval Duration          `yaml:"repeat_interval,omitempty" json:"repeat_interval,omitempty"`
+	Routes         []*Route          `yaml:"routes,omitempty" json:"routes,omitempty"`
+
+	matchers []matcher.Matcher
+}
+
+// Compile validates and compiles this route's matchers and recurses into
+// its children. It must be called once after loading a Config, before the
+// tree is used to route anything.
+func (r *Route) Compile() error {
+	m, err := matcher.Compile(r.Match, r.MatchRe)
+	if err != nil {
+		return err
+	}
+	r.matchers = m
+	for _, child := range r.Routes {
+		if err := child.Compile(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Evaluate returns every route along the tree (starting at r) whose
+// matchers are satisfied by labels, in traversal order. A child is only
+// visited if its matchers hold; once a matching child has been found, its
+// siblings are skipped unless that child sets continue: true. This mirrors
+// Alertmanager's routing semantics.
+func (r *Route) Evaluate(labels map[string]string) []*Route {
+	if !matcher.MatchesAll(r.matchers, labels) {
+		return nil
+	}
+
+	matched := []*Route{r}
+	for _, child := range r.Routes {
+		childMatched := child.Evaluate(labels)
+		matched = append(matched, childMatched...)
+		if len(childMatched) > 0 && !child.Continue {
+			break
+		}
+	}
+	return matched
+}
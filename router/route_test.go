@@ -0,0 +1,62 @@
+package router
+
+import "testing"
+
+func TestRouteMatchContinue(t *testing.T) {
+	root := &Route{
+		Receiver: "default",
+		Routes: []*Route{
+			{
+				Match:    map[string]string{"severity": "CRITICAL"},
+				Receiver: "oncall",
+				Continue: true,
+			},
+			{
+				Match:    map[string]string{"type": "OS"},
+				Receiver: "os-team",
+			},
+			{
+				Match:    map[string]string{"type": "APP"},
+				Receiver: "app-team",
+			},
+		},
+	}
+	if err := root.Compile(); err != nil {
+		t.Fatalf("Compile: %v", err)
+	}
+
+	matched := root.Evaluate(map[string]string{"type": "OS", "severity": "CRITICAL"})
+	var receivers []string
+	for _, r := range matched {
+		receivers = append(receivers, r.Receiver)
+	}
+
+	want := []string{"default", "oncall", "os-team"}
+	if len(receivers) != len(want) {
+		t.Fatalf("receivers = %v, want %v", receivers, want)
+	}
+	for i := range want {
+		if receivers[i] != want[i] {
+			t.Errorf("receivers[%d] = %q, want %q", i, receivers[i], want[i])
+		}
+	}
+}
+
+func TestRouteMatchStopsAtFirstNonContinue(t *testing.T) {
+	root := &Route{
+		Routes: []*Route{
+			{Match: map[string]string{"type": "OS"}, Receiver: "os-team"},
+			{Match: map[string]string{"type": "OS"}, Receiver: "should-not-fire"},
+		},
+	}
+	if err := root.Compile(); err != nil {
+		t.Fatalf("Compile: %v", err)
+	}
+
+	matched := root.Evaluate(map[string]string{"type": "OS"})
+	for _, r := range matched {
+		if r.Receiver == "should-not-fire" {
+			t.Fatalf("expected routing to stop at the first matching non-continue child")
+		}
+	}
+}
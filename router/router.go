@@ -0,0 +1,68 @@
+// Package router implements an Alertmanager-style routing tree that
+// matches vulnerability findings against label matchers, groups matches
+// per-receiver, and fans the grouped findings out to notify sinks.
+package router
+
+import (
+	"strings"
+
+	"github.com/alexfun1/p2s/notify"
+	"github.com/alexfun1/p2s/vuln"
+)
+
+// Router evaluates incoming findings against the live routing tree and
+// dispatches them, grouped, to notify.
+type Router struct {
+	store      *Store
+	dispatcher *notify.Dispatcher
+	grouper    *Grouper
+}
+
+// NewRouter builds a Router backed by store's live routing config,
+// delivering through dispatcher.
+func NewRouter(store *Store, dispatcher *notify.Dispatcher) *Router {
+	r := &Router{store: store, dispatcher: dispatcher}
+	r.grouper = NewGrouper(r.deliver)
+	return r
+}
+
+// Route evaluates v against the current routing tree and enqueues it onto
+// the group for every matching receiver.
+func (r *Router) Route(v vuln.Vulnerability) {
+	cfg := r.store.Get()
+	labels := Labels(v)
+
+	for _, route := range cfg.Route.Evaluate(labels) {
+		if route.Receiver == "" {
+			continue
+		}
+		r.grouper.Add(route, GroupKey(route, labels), v)
+	}
+}
+
+// deliver is the Grouper's flush callback: it resolves route's receiver to
+// its configured sinks and sends every grouped finding to each of them.
+func (r *Router) deliver(route *Route, _ string, findings []vuln.Vulnerability) {
+	cfg := r.store.Get()
+	recv, ok := cfg.Receivers[route.Receiver]
+	if !ok {
+		return
+	}
+	for _, v := range findings {
+		r.dispatcher.SendTo(recv.Sinks, v)
+	}
+}
+
+// Labels converts a Vulnerability into the label set routes match against:
+// its built-in fields plus any custom labels parsed from the payload.
+func Labels(v vuln.Vulnerability) map[string]string {
+	labels := make(map[string]string, len(v.Labels)+4)
+	for k, val := range v.Labels {
+		labels[k] = val
+	}
+	labels["severity"] = strings.ToUpper(v.Severity)
+	labels["type"] = v.Type
+	labels["package_name"] = v.PackageName
+	labels["resource_name"] = v.ResourceName
+	return labels
+}
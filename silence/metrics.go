@@ -0,0 +1,22 @@
+package silence
+
+import "github.com/prometheus/client_golang/prometheus"
+
+// silencedTotal counts findings muted by an active silence, so operators
+// can tell a quiet channel apart from a broken one.
+var silencedTotal = prometheus.NewCounter(
+	prometheus.CounterOpts{
+		Name: "vulns_silenced_total",
+		Help: "Total number of vulnerability findings muted by an active silence",
+	},
+)
+
+func init() {
+	prometheus.MustRegister(silencedTotal)
+}
+
+// RecordSilenced increments the vulns_silenced_total counter. Callers
+// invoke it once per finding muted by Store.Active.
+func RecordSilenced() {
+	silencedTotal.Inc()
+}
@@ -0,0 +1,119 @@
+// Package silence implements an Alertmanager-style silencing store:
+// operators register label matchers plus a time window, and any finding
+// whose labels satisfy an active silence is muted before it reaches a
+// notifier.
+package silence
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/alexfun1/p2s/matcher"
+)
+
+// Silence mutes any finding whose labels satisfy every matcher, for the
+// window between StartsAt and EndsAt.
+type Silence struct {
+	ID        string            `json:"id"`
+	Matchers  map[string]string `json:"matchers,omitempty"`
+	MatchRe   map[string]string `json:"match_re,omitempty"`
+	StartsAt  time.Time         `json:"starts_at"`
+	EndsAt    time.Time         `json:"ends_at"`
+	Comment   string            `json:"comment,omitempty"`
+	CreatedBy string            `json:"created_by,omitempty"`
+
+	compiled []matcher.Matcher
+}
+
+// Store holds active silences in memory behind a mutex. It has no
+// persistence backend yet; operators relying on silences surviving a
+// restart should recreate them via the REST API, or layer a BoltDB/SQLite
+// writer on top of Create/Delete.
+type Store struct {
+	mu       sync.RWMutex
+	silences map[string]*Silence
+	nextID   uint64
+}
+
+// NewStore builds an empty silence Store.
+func NewStore() *Store {
+	return &Store{silences: make(map[string]*Silence)}
+}
+
+// Create compiles sil's matchers and, if they're valid, adds it to the
+// store under a freshly assigned ID.
+func (s *Store) Create(sil *Silence) (*Silence, error) {
+	if sil.EndsAt.Before(sil.StartsAt) {
+		return nil, fmt.Errorf("silence: ends_at is before starts_at")
+	}
+	compiled, err := matcher.Compile(sil.Matchers, sil.MatchRe)
+	if err != nil {
+		return nil, err
+	}
+	sil.compiled = compiled
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.nextID++
+	sil.ID = strconv.FormatUint(s.nextID, 10)
+	s.silences[sil.ID] = sil
+	return sil, nil
+}
+
+// List returns every silence, sorted by ID, for the GET /silences API.
+func (s *Store) List() []*Silence {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	out := make([]*Silence, 0, len(s.silences))
+	for _, sil := range s.silences {
+		out = append(out, sil)
+	}
+	sort.Slice(out, func(i, j int) bool { return idLess(out[i].ID, out[j].ID) })
+	return out
+}
+
+// idLess orders two silence IDs numerically. IDs are always decimal
+// strings from Store's incrementing counter, so a plain string compare
+// would put "10" before "2"; parse failures (which shouldn't happen for
+// IDs this package generates) fall back to the string order.
+func idLess(a, b string) bool {
+	an, aErr := strconv.ParseUint(a, 10, 64)
+	bn, bErr := strconv.ParseUint(b, 10, 64)
+	if aErr != nil || bErr != nil {
+		return a < b
+	}
+	return an < bn
+}
+
+// Delete removes the silence with the given ID, reporting whether it
+// existed.
+func (s *Store) Delete(id string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.silences[id]; !ok {
+		return false
+	}
+	delete(s.silences, id)
+	return true
+}
+
+// Active reports whether labels is muted by a currently active silence
+// (now within [StartsAt, EndsAt)), returning the matching silence if so.
+func (s *Store) Active(labels map[string]string, now time.Time) (*Silence, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, sil := range s.silences {
+		if now.Before(sil.StartsAt) || !now.Before(sil.EndsAt) {
+			continue
+		}
+		if matcher.MatchesAll(sil.compiled, labels) {
+			return sil, true
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,63 @@
+package silence
+
+import (
+	"testing"
+	"time"
+)
+
+func TestStoreActive(t *testing.T) {
+	s := NewStore()
+	now := time.Now()
+
+	sil, err := s.Create(&Silence{
+		Matchers: map[string]string{"resource_name": "vm-1"},
+		StartsAt: now.Add(-time.Minute),
+		EndsAt:   now.Add(time.Hour),
+		Comment:  "maintenance window",
+	})
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if sil.ID == "" {
+		t.Fatal("Create did not assign an ID")
+	}
+
+	if _, muted := s.Active(map[string]string{"resource_name": "vm-1"}, now); !muted {
+		t.Error("expected matching resource to be muted")
+	}
+	if _, muted := s.Active(map[string]string{"resource_name": "vm-2"}, now); muted {
+		t.Error("expected non-matching resource to be unmuted")
+	}
+	if _, muted := s.Active(map[string]string{"resource_name": "vm-1"}, now.Add(2*time.Hour)); muted {
+		t.Error("expected expired silence to be inactive")
+	}
+
+	if !s.Delete(sil.ID) {
+		t.Fatal("Delete reported the silence did not exist")
+	}
+	if _, muted := s.Active(map[string]string{"resource_name": "vm-1"}, now); muted {
+		t.Error("expected deleted silence to no longer mute")
+	}
+}
+
+func TestStoreListOrdersIDsNumerically(t *testing.T) {
+	s := NewStore()
+	now := time.Now()
+
+	for i := 0; i < 11; i++ {
+		if _, err := s.Create(&Silence{StartsAt: now, EndsAt: now.Add(time.Hour)}); err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+	}
+
+	out := s.List()
+	if len(out) != 11 {
+		t.Fatalf("List returned %d silences, want 11", len(out))
+	}
+	want := []string{"1", "2", "3", "4", "5", "6", "7", "8", "9", "10", "11"}
+	for i, sil := range out {
+		if sil.ID != want[i] {
+			t.Errorf("out[%d].ID = %q, want %q", i, sil.ID, want[i])
+		}
+	}
+}
@@ -0,0 +1,322 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	_ "modernc.org/sqlite"
+
+	"github.com/alexfun1/p2s/vuln"
+)
+
+const schema = `
+CREATE TABLE IF NOT EXISTS findings (
+	id               INTEGER PRIMARY KEY AUTOINCREMENT,
+	fingerprint      TEXT UNIQUE NOT NULL,
+	severity         TEXT NOT NULL,
+	type             TEXT NOT NULL,
+	description      TEXT NOT NULL,
+	package_name     TEXT NOT NULL,
+	resource_name    TEXT NOT NULL,
+	labels           TEXT NOT NULL DEFAULT '{}',
+	first_seen       DATETIME NOT NULL,
+	last_seen        DATETIME NOT NULL,
+	occurrence_count INTEGER NOT NULL DEFAULT 1,
+	acknowledged_by  TEXT,
+	acknowledged_at  DATETIME
+);
+
+CREATE TABLE IF NOT EXISTS notifications (
+	finding_id INTEGER NOT NULL REFERENCES findings(id),
+	sink       TEXT NOT NULL,
+	sent_at    DATETIME NOT NULL
+);
+`
+
+// sqliteStore is the default Store implementation, backed by the pure-Go
+// modernc.org/sqlite driver so p2s stays a single static binary with no
+// CGo toolchain requirement.
+type sqliteStore struct {
+	db *sql.DB
+}
+
+// NewSQLite opens (creating if necessary) a SQLite database at path and
+// migrates its schema.
+func NewSQLite(path string) (Store, error) {
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, fmt.Errorf("store: opening sqlite database: %w", err)
+	}
+	if _, err := db.Exec(schema); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("store: migrating schema: %w", err)
+	}
+	return &sqliteStore{db: db}, nil
+}
+
+func (s *sqliteStore) Close() error { return s.db.Close() }
+
+// Record upserts v by fingerprint: a first sighting inserts a new row with
+// OccurrenceCount 1; a repeat bumps LastSeen and OccurrenceCount.
+func (s *sqliteStore) Record(ctx context.Context, v vuln.Vulnerability, now time.Time) (*Finding, error) {
+	fp := vuln.Fingerprint(v)
+	labels, err := json.Marshal(v.Labels)
+	if err != nil {
+		return nil, fmt.Errorf("store: marshaling labels: %w", err)
+	}
+
+	_, err = s.db.ExecContext(ctx, `
+		INSERT INTO findings (fingerprint, severity, type, description, package_name, resource_name, labels, first_seen, last_seen, occurrence_count)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, 1)
+		ON CONFLICT(fingerprint) DO UPDATE SET
+			last_seen = excluded.last_seen,
+			description = excluded.description,
+			occurrence_count = occurrence_count + 1
+	`, fp, v.Severity, v.Type, v.Description, v.PackageName, v.ResourceName, string(labels), now, now)
+	if err != nil {
+		return nil, fmt.Errorf("store: recording finding: %w", err)
+	}
+
+	return s.getByFingerprint(ctx, fp)
+}
+
+// RecordNotification appends a delivery attempt to the finding identified
+// by fingerprint's history.
+func (s *sqliteStore) RecordNotification(ctx context.Context, fingerprint, sink string, sentAt time.Time) error {
+	row := s.db.QueryRowContext(ctx, `SELECT id FROM findings WHERE fingerprint = ?`, fingerprint)
+	var id int64
+	if err := row.Scan(&id); err != nil {
+		return fmt.Errorf("store: looking up finding %q: %w", fingerprint, err)
+	}
+	_, err := s.db.ExecContext(ctx, `INSERT INTO notifications (finding_id, sink, sent_at) VALUES (?, ?, ?)`, id, sink, sentAt)
+	if err != nil {
+		return fmt.Errorf("store: recording notification: %w", err)
+	}
+	return nil
+}
+
+// List returns findings matching filter, most recently seen first.
+func (s *sqliteStore) List(ctx context.Context, filter Filter) ([]*Finding, error) {
+	where := []string{"1 = 1"}
+	args := []interface{}{}
+
+	if filter.Severity != "" {
+		where = append(where, "severity = ?")
+		args = append(args, filter.Severity)
+	}
+	if filter.Type != "" {
+		where = append(where, "type = ?")
+		args = append(args, filter.Type)
+	}
+	if filter.PackageName != "" {
+		where = append(where, "package_name = ?")
+		args = append(args, filter.PackageName)
+	}
+	if filter.ResourceName != "" {
+		where = append(where, "resource_name = ?")
+		args = append(args, filter.ResourceName)
+	}
+	if filter.OnlyUnacked {
+		where = append(where, "acknowledged_at IS NULL")
+	}
+
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = 50
+	}
+
+	query := fmt.Sprintf(`
+		SELECT id, fingerprint, severity, type, description, package_name, resource_name, labels,
+		       first_seen, last_seen, occurrence_count, acknowledged_by, acknowledged_at
+		FROM findings
+		WHERE %s
+		ORDER BY last_seen DESC
+		LIMIT ? OFFSET ?
+	`, strings.Join(where, " AND "))
+	args = append(args, limit, filter.Offset)
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("store: listing findings: %w", err)
+	}
+	defer rows.Close()
+
+	var findings []*Finding
+	for rows.Next() {
+		f, err := scanFinding(rows)
+		if err != nil {
+			return nil, err
+		}
+		findings = append(findings, f)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := s.loadNotifications(ctx, findings); err != nil {
+		return nil, err
+	}
+	return findings, nil
+}
+
+// loadNotifications fills in each finding's Notifications from the
+// notifications table in a single query, rather than one per finding.
+func (s *sqliteStore) loadNotifications(ctx context.Context, findings []*Finding) error {
+	if len(findings) == 0 {
+		return nil
+	}
+	byID := make(map[int64]*Finding, len(findings))
+	placeholders := make([]string, len(findings))
+	args := make([]interface{}, len(findings))
+	for i, f := range findings {
+		byID[f.ID] = f
+		placeholders[i] = "?"
+		args[i] = f.ID
+	}
+
+	query := fmt.Sprintf(`
+		SELECT finding_id, sink, sent_at FROM notifications
+		WHERE finding_id IN (%s)
+		ORDER BY sent_at ASC
+	`, strings.Join(placeholders, ", "))
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("store: loading notification history: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var findingID int64
+		var n Notification
+		if err := rows.Scan(&findingID, &n.Sink, &n.SentAt); err != nil {
+			return fmt.Errorf("store: scanning notification: %w", err)
+		}
+		byID[findingID].Notifications = append(byID[findingID].Notifications, n)
+	}
+	return rows.Err()
+}
+
+// Ack marks the finding with the given ID acknowledged.
+func (s *sqliteStore) Ack(ctx context.Context, id int64, by string, at time.Time) error {
+	res, err := s.db.ExecContext(ctx, `UPDATE findings SET acknowledged_by = ?, acknowledged_at = ? WHERE id = ?`, by, at, id)
+	if err != nil {
+		return fmt.Errorf("store: acknowledging finding %d: %w", id, err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("store: acknowledging finding %d: %w", id, err)
+	}
+	if n == 0 {
+		return fmt.Errorf("store: finding %d not found", id)
+	}
+	return nil
+}
+
+// Summary computes severity counts and the top 5 packages/resources by
+// occurrence count across all findings.
+func (s *sqliteStore) Summary(ctx context.Context) (*Summary, error) {
+	sum := &Summary{BySeverity: make(map[string]int)}
+
+	rows, err := s.db.QueryContext(ctx, `SELECT severity, COUNT(*) FROM findings GROUP BY severity`)
+	if err != nil {
+		return nil, fmt.Errorf("store: summarizing by severity: %w", err)
+	}
+	for rows.Next() {
+		var severity string
+		var count int
+		if err := rows.Scan(&severity, &count); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		sum.BySeverity[severity] = count
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	sum.TopPackages, err = s.topN(ctx, "package_name")
+	if err != nil {
+		return nil, err
+	}
+	sum.TopResources, err = s.topN(ctx, "resource_name")
+	if err != nil {
+		return nil, err
+	}
+	return sum, nil
+}
+
+func (s *sqliteStore) topN(ctx context.Context, column string) ([]NamedCount, error) {
+	rows, err := s.db.QueryContext(ctx, fmt.Sprintf(`
+		SELECT %s, SUM(occurrence_count) AS total
+		FROM findings
+		GROUP BY %s
+		ORDER BY total DESC
+		LIMIT 5
+	`, column, column))
+	if err != nil {
+		return nil, fmt.Errorf("store: summarizing by %s: %w", column, err)
+	}
+	defer rows.Close()
+
+	var out []NamedCount
+	for rows.Next() {
+		var nc NamedCount
+		if err := rows.Scan(&nc.Name, &nc.Count); err != nil {
+			return nil, err
+		}
+		out = append(out, nc)
+	}
+	return out, rows.Err()
+}
+
+func (s *sqliteStore) getByFingerprint(ctx context.Context, fingerprint string) (*Finding, error) {
+	row := s.db.QueryRowContext(ctx, `
+		SELECT id, fingerprint, severity, type, description, package_name, resource_name, labels,
+		       first_seen, last_seen, occurrence_count, acknowledged_by, acknowledged_at
+		FROM findings WHERE fingerprint = ?
+	`, fingerprint)
+	f, err := scanFinding(row)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.loadNotifications(ctx, []*Finding{f}); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// rowScanner is satisfied by both *sql.Row and *sql.Rows.
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanFinding(row rowScanner) (*Finding, error) {
+	var f Finding
+	var labels string
+	var ackBy sql.NullString
+	var ackAt sql.NullTime
+
+	if err := row.Scan(
+		&f.ID, &f.Fingerprint, &f.Severity, &f.Type, &f.Description, &f.PackageName, &f.ResourceName, &labels,
+		&f.FirstSeen, &f.LastSeen, &f.OccurrenceCount, &ackBy, &ackAt,
+	); err != nil {
+		return nil, fmt.Errorf("store: scanning finding: %w", err)
+	}
+
+	if err := json.Unmarshal([]byte(labels), &f.Labels); err != nil {
+		return nil, fmt.Errorf("store: unmarshaling labels: %w", err)
+	}
+	if ackBy.Valid {
+		f.AcknowledgedBy = ackBy.String
+	}
+	if ackAt.Valid {
+		t := ackAt.Time
+		f.AcknowledgedAt = &t
+	}
+	return &f, nil
+}
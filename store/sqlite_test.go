@@ -0,0 +1,79 @@
+package store
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/alexfun1/p2s/vuln"
+)
+
+func TestSQLiteRecordListAck(t *testing.T) {
+	ctx := context.Background()
+	s, err := NewSQLite(filepath.Join(t.TempDir(), "findings.db"))
+	if err != nil {
+		t.Fatalf("NewSQLite: %v", err)
+	}
+	defer s.Close()
+
+	v := vuln.Vulnerability{Severity: "HIGH", Type: "OS", PackageName: "openssl", ResourceName: "vm-1", Description: "first"}
+	now := time.Now()
+
+	first, err := s.Record(ctx, v, now)
+	if err != nil {
+		t.Fatalf("Record: %v", err)
+	}
+	if first.OccurrenceCount != 1 {
+		t.Errorf("OccurrenceCount = %d, want 1", first.OccurrenceCount)
+	}
+
+	v.Description = "second"
+	second, err := s.Record(ctx, v, now.Add(time.Minute))
+	if err != nil {
+		t.Fatalf("Record (repeat): %v", err)
+	}
+	if second.ID != first.ID {
+		t.Fatalf("repeat sighting got a new ID: %d != %d", second.ID, first.ID)
+	}
+	if second.OccurrenceCount != 2 {
+		t.Errorf("OccurrenceCount = %d, want 2", second.OccurrenceCount)
+	}
+
+	if err := s.RecordNotification(ctx, vuln.Fingerprint(v), "slack-os", now); err != nil {
+		t.Fatalf("RecordNotification: %v", err)
+	}
+
+	list, err := s.List(ctx, Filter{OnlyUnacked: true})
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if len(list) != 1 {
+		t.Fatalf("List returned %d findings, want 1", len(list))
+	}
+	if len(list[0].Notifications) != 1 || list[0].Notifications[0].Sink != "slack-os" {
+		t.Errorf("Notifications = %+v, want a single slack-os entry", list[0].Notifications)
+	}
+
+	if err := s.Ack(ctx, first.ID, "alice", now); err != nil {
+		t.Fatalf("Ack: %v", err)
+	}
+	list, err = s.List(ctx, Filter{OnlyUnacked: true})
+	if err != nil {
+		t.Fatalf("List after ack: %v", err)
+	}
+	if len(list) != 0 {
+		t.Fatalf("List returned %d unacked findings after Ack, want 0", len(list))
+	}
+
+	summary, err := s.Summary(ctx)
+	if err != nil {
+		t.Fatalf("Summary: %v", err)
+	}
+	if summary.BySeverity["HIGH"] != 1 {
+		t.Errorf("BySeverity[HIGH] = %d, want 1", summary.BySeverity["HIGH"])
+	}
+	if len(summary.TopPackages) != 1 || summary.TopPackages[0].Name != "openssl" {
+		t.Errorf("TopPackages = %+v, want one entry for openssl", summary.TopPackages)
+	}
+}
@@ -0,0 +1,79 @@
+// Package store persists every Vulnerability p2s sees, turning it from a
+// fire-and-forget notifier into a lightweight findings tracker queryable
+// over HTTP.
+package store
+
+import (
+	"context"
+	"time"
+
+	"github.com/alexfun1/p2s/vuln"
+)
+
+// Finding is a tracked Vulnerability plus the bookkeeping the store adds:
+// when it was first/last seen, how many times, and what's happened to it
+// since.
+type Finding struct {
+	vuln.Vulnerability
+
+	ID              int64          `json:"id"`
+	Fingerprint     string         `json:"fingerprint"`
+	FirstSeen       time.Time      `json:"first_seen"`
+	LastSeen        time.Time      `json:"last_seen"`
+	OccurrenceCount int            `json:"occurrence_count"`
+	AcknowledgedBy  string         `json:"acknowledged_by,omitempty"`
+	AcknowledgedAt  *time.Time     `json:"acknowledged_at,omitempty"`
+	Notifications   []Notification `json:"notification_history,omitempty"`
+}
+
+// Notification records one attempt to deliver a Finding to a notify sink.
+type Notification struct {
+	Sink   string    `json:"sink"`
+	SentAt time.Time `json:"sent_at"`
+}
+
+// Filter narrows List to a subset of findings, with simple offset
+// pagination.
+type Filter struct {
+	Severity      string
+	Type          string
+	PackageName   string
+	ResourceName  string
+	OnlyUnacked   bool
+	Limit, Offset int
+}
+
+// NamedCount is one row of a "top N" aggregate, e.g. the most frequently
+// affected packages.
+type NamedCount struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+// Summary is the dashboard's at-a-glance view of the current findings.
+type Summary struct {
+	BySeverity   map[string]int
+	TopPackages  []NamedCount
+	TopResources []NamedCount
+}
+
+// Store is the persistence backend for findings. SQLite (Store's only
+// built-in implementation, see NewSQLite) is the default; a Postgres
+// implementation can be added behind the same interface without touching
+// callers.
+type Store interface {
+	// Record upserts v: inserts a new Finding on first sighting of its
+	// fingerprint, or bumps LastSeen/OccurrenceCount on a repeat.
+	Record(ctx context.Context, v vuln.Vulnerability, now time.Time) (*Finding, error)
+	// RecordNotification appends a delivery attempt to a finding's
+	// notification_history.
+	RecordNotification(ctx context.Context, fingerprint, sink string, sentAt time.Time) error
+	// List returns findings matching filter, most recently seen first.
+	List(ctx context.Context, filter Filter) ([]*Finding, error)
+	// Ack marks the finding with the given ID acknowledged.
+	Ack(ctx context.Context, id int64, by string, at time.Time) error
+	// Summary computes the dashboard aggregates.
+	Summary(ctx context.Context) (*Summary, error)
+	// Close releases the store's underlying resources.
+	Close() error
+}
@@ -0,0 +1,42 @@
+// Package vuln holds the domain types shared by p2s's ingestion, routing,
+// and notification packages.
+package vuln
+
+import "strings"
+
+// Vulnerability is a single finding reported by a scanner (GCP Security
+// Command Center, Pub/Sub bridge, etc).
+type Vulnerability struct {
+	Severity     string `json:"severity"`
+	Type         string `json:"type"` // "OS" or "APP"
+	Description  string `json:"description"`
+	PackageName  string `json:"package_name"`
+	ResourceName string `json:"resource_name"`
+
+	// Labels carries arbitrary custom key/value pairs parsed from the
+	// originating payload so routing rules can match on more than the
+	// built-in fields above.
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+// SeverityLevels ranks severities from least to most urgent so callers can
+// compare a finding against a configured minimum.
+var SeverityLevels = map[string]int{
+	"LOW":      1,
+	"MEDIUM":   2,
+	"HIGH":     3,
+	"CRITICAL": 4,
+}
+
+// SeverityRank returns the numeric rank for severity, or 0 if it is not a
+// recognized level.
+func SeverityRank(severity string) int {
+	return SeverityLevels[severity]
+}
+
+// Fingerprint identifies v independent of its free-text description, so
+// dedup and the findings store can recognize repeated deliveries of "the
+// same" finding.
+func Fingerprint(v Vulnerability) string {
+	return strings.Join([]string{v.Type, v.PackageName, v.ResourceName, strings.ToUpper(v.Severity)}, "\x00")
+}